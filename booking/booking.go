@@ -0,0 +1,386 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	impersonation "github.com/slav123/ews-workmail/ews-impersonation"
+)
+
+const (
+	// defaultDays is the number of days of slots served when the request gives no end date
+	defaultDays = 7
+	// defaultMaxDays is the longest range of slots served in one request when none is configured
+	defaultMaxDays = 31
+	// maxBookingBodySize limits the size of a booking submission
+	maxBookingBodySize = 64 << 10
+	// maxNameLength limits the length of a guest's name, which ends up in the event subject
+	maxNameLength = 200
+	// maxNotesLength limits the length of a guest's notes, which end up in the event body
+	maxNotesLength = 5000
+	// dateLayout is the format of the from and to query parameters
+	dateLayout = "2006-01-02"
+)
+
+// ErrRateLimited is returned by a Handler's Authorize to reject a booking with 429 Too Many Requests
+var ErrRateLimited = errors.New("too many bookings")
+
+// Type is a kind of meeting guests can book, e.g. a 30 minute introduction call
+type Type struct {
+	ID          string        // Identifies the type in URLs, e.g. "intro-call"
+	Title       string        // Shown to guests and used in the event subject, e.g. "Introduction call"
+	Description string        // Shown to guests and added to the event body
+	Duration    time.Duration // Length of the meeting
+	Location    string        // Location of the event, e.g. a video call link
+	// Options are the availability rules of the type, e.g. working hours, buffers and minimum notice.
+	// Days are those of the time zone of Options.WorkingHours, or of the handler's Location.
+	Options impersonation.AvailabilityOptions
+}
+
+// Handler serves a booking page's API for one mailbox. Guests list the booking types, read the
+// available slots of a type as JSON and book one with their name and email address; the event is
+// created in the mailbox with the guest as required attendee. Routes, relative to where the handler
+// is mounted (use http.StripPrefix when mounting it under a path):
+//
+//	GET  /types                      the booking types
+//	GET  /types/{type}/slots         available slots; from and to select the days, e.g. ?from=2025-06-02&to=2025-06-06
+//	POST /types/{type}/bookings      books a slot; the JSON body holds start, name, email and optional notes
+//
+// Bookings need no authentication and send an invitation to the address the guest gives, so a public
+// page can be used to send mail to anyone. Set Authorize, or wrap the handler in rate limiting or
+// authentication, before exposing it.
+type Handler struct {
+	Client  *impersonation.ImpersonationClient
+	Mailbox string // Address of the mailbox whose calendar is booked, e.g. a sales rep's
+	Types   []Type
+	// Location is the time zone of the days given in requests for types without working hours;
+	// defaults to the local time zone
+	Location *time.Location
+	// MaxDays is the longest range of days served in one slots request; defaults to 31
+	MaxDays int
+	// ErrorLog receives the errors of the mailbox that are not shown to guests; defaults to the
+	// standard logger
+	ErrorLog *log.Logger
+	// Authorize, if set, is called with each valid booking before the calendar is read, e.g. to check
+	// a CAPTCHA token sent in a header, limit the bookings per client address or restrict the guests'
+	// domains. Returning ErrRateLimited rejects the booking with 429 Too Many Requests, any other
+	// error with 403 Forbidden.
+	Authorize func(r *http.Request, req Request) error
+
+	muxOnce sync.Once
+	mux     *http.ServeMux
+}
+
+// NewHandler returns a handler for booking the given types in the mailbox
+func NewHandler(client *impersonation.ImpersonationClient, mailbox string, types ...Type) *Handler {
+	return &Handler{Client: client, Mailbox: mailbox, Types: types}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.muxOnce.Do(h.routes)
+	h.mux.ServeHTTP(w, r)
+}
+
+// routes registers the routes of the handler, so a Handler built without NewHandler works too
+func (h *Handler) routes() {
+	h.mux = http.NewServeMux()
+	h.mux.HandleFunc("GET /types", h.listTypes)
+	h.mux.HandleFunc("GET /types/{type}/slots", h.listSlots)
+	h.mux.HandleFunc("POST /types/{type}/bookings", h.book)
+}
+
+// TypeInfo describes a booking type to guests
+type TypeInfo struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Description     string `json:"description,omitempty"`
+	DurationMinutes int    `json:"duration_minutes"`
+	Location        string `json:"location,omitempty"`
+}
+
+// Slot is an available time, in the time zone of the booking type
+type Slot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Request is a booking submitted by a guest
+type Request struct {
+	Start time.Time `json:"start"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Notes string    `json:"notes,omitempty"`
+}
+
+// Confirmation is returned for a booked slot
+type Confirmation struct {
+	Slot
+	Type string `json:"type"`
+	// InvitationSent is false when the event was booked but the invitation could not be sent to the guest
+	InvitationSent bool `json:"invitation_sent"`
+}
+
+// errorResponse is the body of error responses
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) listTypes(w http.ResponseWriter, r *http.Request) {
+	types := make([]TypeInfo, 0, len(h.Types))
+	for _, t := range h.Types {
+		types = append(types, TypeInfo{
+			ID:              t.ID,
+			Title:           t.Title,
+			Description:     t.Description,
+			DurationMinutes: int(t.Duration / time.Minute),
+			Location:        t.Location,
+		})
+	}
+	h.writeJSON(w, http.StatusOK, types)
+}
+
+func (h *Handler) listSlots(w http.ResponseWriter, r *http.Request) {
+	bookingType, ok := h.bookingType(r.PathValue("type"))
+	if !ok {
+		h.writeError(w, http.StatusNotFound, "unknown booking type")
+		return
+	}
+
+	start, end, err := h.dayRange(r, bookingType)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !end.After(start) {
+		h.writeJSON(w, http.StatusOK, []Slot{})
+		return
+	}
+
+	slots, err := h.Client.GetAvailableSlots(r.Context(), start, end, bookingType.Duration, bookingType.Options, h.Mailbox)
+	if err != nil {
+		h.serverError(w, "reading available slots", err)
+		return
+	}
+
+	loc := h.location(bookingType)
+	available := make([]Slot, 0, len(slots))
+	for _, slot := range slots {
+		available = append(available, Slot{Start: slot.Start.In(loc), End: slot.End.In(loc)})
+	}
+	h.writeJSON(w, http.StatusOK, available)
+}
+
+func (h *Handler) book(w http.ResponseWriter, r *http.Request) {
+	bookingType, ok := h.bookingType(r.PathValue("type"))
+	if !ok {
+		h.writeError(w, http.StatusNotFound, "unknown booking type")
+		return
+	}
+
+	var req Request
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBookingBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid booking request")
+		return
+	}
+	guest, err := req.guest()
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if h.Authorize != nil {
+		switch err := h.Authorize(r, req); {
+		case errors.Is(err, ErrRateLimited):
+			h.writeError(w, http.StatusTooManyRequests, "too many bookings, please try again later")
+			return
+		case err != nil:
+			h.writeError(w, http.StatusForbidden, "booking is not allowed")
+			return
+		}
+	}
+
+	loc := h.location(bookingType)
+	slot := Slot{Start: req.Start.In(loc), End: req.Start.In(loc).Add(bookingType.Duration)}
+	if !slot.Start.After(time.Now()) {
+		h.writeError(w, http.StatusConflict, "slot is not available")
+		return
+	}
+
+	// Only book the slots the page offers, which start on the type's step
+	offered, err := h.Client.GetAvailableSlots(r.Context(), slot.Start, slot.End, bookingType.Duration, bookingType.Options, h.Mailbox)
+	if err != nil {
+		h.serverError(w, "checking slot", err)
+		return
+	}
+	if len(offered) != 1 || !offered[0].Start.Equal(slot.Start) {
+		h.writeError(w, http.StatusConflict, "slot is not available")
+		return
+	}
+
+	// Invitations are only sent once the booking has won the slot, so a guest who loses a race for
+	// it is not left with an invitation to a meeting that does not exist
+	event := impersonation.CalendarEvent{
+		Subject:           fmt.Sprintf("%s with %s", bookingType.Title, guest.Name),
+		Body:              eventBody(bookingType, guest, req.Notes),
+		Start:             slot.Start,
+		End:               slot.End,
+		Location:          bookingType.Location,
+		RequiredAttendees: []impersonation.Attendee{guest},
+	}
+	itemID, err := h.Client.BookSlot(r.Context(), event, "SendToNone", bookingType.Options, h.Mailbox)
+	var conflict *impersonation.SlotConflictError
+	switch {
+	case errors.As(err, &conflict):
+		h.writeError(w, http.StatusConflict, "slot is not available")
+		return
+	case err != nil:
+		h.serverError(w, "booking slot", err)
+		return
+	}
+
+	confirmation := Confirmation{Slot: slot, Type: bookingType.ID, InvitationSent: true}
+	updates := impersonation.EventUpdates{RequiredAttendees: []impersonation.Attendee{guest}}
+	err = h.Client.UpdateCalendarEvent(r.Context(), itemID.Id, itemID.ChangeKey, updates, "AlwaysOverwrite", "SendToAllAndSaveCopy", h.Mailbox)
+	if err != nil {
+		h.logf("error sending invitation for booking %s to %s: %v", slot.Start.Format(time.RFC3339), guest.Email, err)
+		confirmation.InvitationSent = false
+	}
+	h.writeJSON(w, http.StatusCreated, confirmation)
+}
+
+// guest validates the guest's details of the request
+func (req Request) guest() (impersonation.Attendee, error) {
+	name := strings.TrimSpace(req.Name)
+	switch {
+	case req.Start.IsZero():
+		return impersonation.Attendee{}, errors.New("start is required")
+	case name == "":
+		return impersonation.Attendee{}, errors.New("name is required")
+	case len(name) > maxNameLength || strings.ContainsAny(name, "\r\n"):
+		return impersonation.Attendee{}, errors.New("invalid name")
+	case len(req.Notes) > maxNotesLength:
+		return impersonation.Attendee{}, fmt.Errorf("notes are limited to %d characters", maxNotesLength)
+	}
+
+	address, err := mail.ParseAddress(req.Email)
+	if err != nil || address.Name != "" {
+		return impersonation.Attendee{}, errors.New("invalid email address")
+	}
+	return impersonation.Attendee{Name: name, Email: address.Address}, nil
+}
+
+// eventBody is the body of the booked event, holding the type's description and the guest's notes
+func eventBody(bookingType Type, guest impersonation.Attendee, notes string) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Booked by %s <%s>\n", guest.Name, guest.Email)
+	if bookingType.Description != "" {
+		fmt.Fprintf(&body, "\n%s\n", bookingType.Description)
+	}
+	if notes = strings.TrimSpace(notes); notes != "" {
+		fmt.Fprintf(&body, "\nNotes:\n%s\n", notes)
+	}
+	return body.String()
+}
+
+func (h *Handler) bookingType(id string) (Type, bool) {
+	for _, t := range h.Types {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+// location returns the time zone of the days of the booking type
+func (h *Handler) location(bookingType Type) *time.Location {
+	switch {
+	case bookingType.Options.WorkingHours != nil && bookingType.Options.WorkingHours.Location != nil:
+		return bookingType.Options.WorkingHours.Location
+	case h.Location != nil:
+		return h.Location
+	}
+	return time.Local
+}
+
+// dayRange returns the range of the days selected by the from and to query parameters, both
+// inclusive, starting no earlier than now. It defaults to the 7 days starting today.
+func (h *Handler) dayRange(r *http.Request, bookingType Type) (time.Time, time.Time, error) {
+	loc := h.location(bookingType)
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if value := r.URL.Query().Get("from"); value != "" {
+		var err error
+		if from, err = time.ParseInLocation(dateLayout, value, loc); err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be a date like 2006-01-02")
+		}
+	}
+
+	to := from.AddDate(0, 0, defaultDays-1)
+	if value := r.URL.Query().Get("to"); value != "" {
+		var err error
+		if to, err = time.ParseInLocation(dateLayout, value, loc); err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be a date like 2006-01-02")
+		}
+	}
+
+	maxDays := h.MaxDays
+	if maxDays <= 0 {
+		maxDays = defaultMaxDays
+	}
+	switch {
+	case to.Before(from):
+		return time.Time{}, time.Time{}, errors.New("to must not be before from")
+	case to.After(from.AddDate(0, 0, maxDays-1)):
+		return time.Time{}, time.Time{}, fmt.Errorf("at most %d days can be requested", maxDays)
+	}
+
+	// Past slots are never offered, so the range starts now at the earliest and is empty when
+	// every selected day has passed
+	start, end := from, to.AddDate(0, 0, 1)
+	if start.Before(now) {
+		start = now
+	}
+	if end.Before(start) {
+		end = start
+	}
+	return start, end, nil
+}
+
+// serverError logs an error of the mailbox and tells the guest that the request failed
+func (h *Handler) serverError(w http.ResponseWriter, action string, err error) {
+	h.logf("error %s in %s: %v", action, h.Mailbox, err)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	h.writeError(w, http.StatusBadGateway, "the calendar is not available, please try again later")
+}
+
+func (h *Handler) logf(format string, args ...any) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+	h.writeJSON(w, status, errorResponse{Error: message})
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logf("error writing response: %v", err)
+	}
+}
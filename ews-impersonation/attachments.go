@@ -0,0 +1,261 @@
+package ewsimpersonation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// attachmentContentPlaceholder marks where the streamed attachment content is spliced into the request
+const attachmentContentPlaceholder = "__EWS_ATTACHMENT_CONTENT__"
+
+// contentStartTag matches the start tag of an attachment Content element, with or without a namespace prefix
+var contentStartTag = regexp.MustCompile(`^<([A-Za-z_][\w.-]*:)?Content(\s[^>]*)?>$`)
+
+// responseClassAttr matches the ResponseClass attribute of a response message start tag
+var responseClassAttr = regexp.MustCompile(`\sResponseClass="([^"]*)"`)
+
+// CreateAttachment adds a file attachment to an existing item of the target user, such as a calendar event.
+// The content is base64 encoded while it is being sent, so large files are never held in memory.
+// size is the length of content in bytes, which sets the length of the request.
+// It returns the ID of the new attachment, which also carries the updated root item ChangeKey.
+func (c *ImpersonationClient) CreateAttachment(ctx context.Context, parentItemId, parentChangeKey, name, contentType string, content io.Reader, size int64, targetUserEmail string) (*AttachmentId, error) {
+	request := &CreateAttachmentRequest{
+		XMLNSm:       "http://schemas.microsoft.com/exchange/services/2006/messages",
+		ParentItemId: ItemId{Id: parentItemId, ChangeKey: parentChangeKey},
+		Attachments: CreateAttachments{
+			FileAttachment: []CreateFileAttachment{
+				{Name: name, ContentType: contentType, Content: attachmentContentPlaceholder},
+			},
+		},
+	}
+
+	xmlData, err := c.marshalEnvelope(targetUserEmail, request)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, length, closePayload, err := streamAttachmentPayload(xmlData, content, size)
+	if err != nil {
+		return nil, err
+	}
+	defer closePayload()
+
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/CreateAttachment"
+	resp, err := c.post(ctx, soapAction, payload, length)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading EWS response body: %w", err)
+	}
+
+	var responseEnvelope CreateAttachmentResponseEnvelope
+	if err := xml.Unmarshal(bodyBytes, &responseEnvelope); err != nil {
+		return nil, fmt.Errorf("error unmarshalling EWS response: %w. Response body: %s", err, string(bodyBytes))
+	}
+
+	respMsg := responseEnvelope.Body.CreateAttachmentResponse.ResponseMessages.CreateAttachmentResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error creating attachment: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	if len(respMsg.Attachments) == 0 {
+		return nil, fmt.Errorf("created attachment ID not found in EWS response")
+	}
+
+	return &respMsg.Attachments[0].AttachmentId, nil
+}
+
+// GetAttachment streams the decoded content of a file attachment of the target user to w and returns its metadata.
+// The base64 content is decoded as it is read from the response, so large files are never held in memory.
+// Nothing is written to w for an error response, but w may hold part of the content if reading fails.
+func (c *ImpersonationClient) GetAttachment(ctx context.Context, attachmentId string, w io.Writer, targetUserEmail string) (*FileAttachment, error) {
+	request := &GetAttachmentRequest{
+		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
+		AttachmentIds: AttachmentIds{
+			AttachmentId: []AttachmentId{{Id: attachmentId}},
+		},
+	}
+
+	xmlData, err := c.marshalEnvelope(targetUserEmail, request)
+	if err != nil {
+		return nil, err
+	}
+
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetAttachment"
+	resp, err := c.post(ctx, soapAction, bytes.NewReader(xmlData), int64(len(xmlData)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Stream the content out and keep the rest of the document for parsing
+	bodyBytes, err := extractAttachmentContent(resp.Body, w)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseEnvelope GetAttachmentResponseEnvelope
+	if err := xml.Unmarshal(bodyBytes, &responseEnvelope); err != nil {
+		return nil, fmt.Errorf("error unmarshalling EWS response: %w. Response body: %s", err, string(bodyBytes))
+	}
+
+	respMsg := responseEnvelope.Body.GetAttachmentResponse.ResponseMessages.GetAttachmentResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error getting attachment: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	if len(respMsg.Attachments) == 0 {
+		return nil, fmt.Errorf("attachment not found in EWS response")
+	}
+
+	return &respMsg.Attachments[0], nil
+}
+
+// DeleteAttachment removes an attachment from an item of the target user.
+func (c *ImpersonationClient) DeleteAttachment(ctx context.Context, attachmentId string, targetUserEmail string) error {
+	request := &DeleteAttachmentRequest{
+		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
+		AttachmentIds: AttachmentIds{
+			AttachmentId: []AttachmentId{{Id: attachmentId}},
+		},
+	}
+
+	var responseEnvelope DeleteAttachmentResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/DeleteAttachment"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return err
+	}
+
+	respMsg := responseEnvelope.Body.DeleteAttachmentResponse.ResponseMessages.DeleteAttachmentResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return fmt.Errorf("EWS error deleting attachment: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	return nil
+}
+
+// streamAttachmentPayload splices the base64 encoding of content into the marshalled
+// request in place of attachmentContentPlaceholder. The returned close function stops
+// the encoder if the request is abandoned before the content has been fully read.
+func streamAttachmentPayload(xmlData []byte, content io.Reader, size int64) (io.Reader, int64, func(), error) {
+	if size < 0 {
+		return nil, 0, nil, fmt.Errorf("attachment size must not be negative")
+	}
+	// The placeholder is searched from the end so a file name containing it cannot confuse the split
+	i := bytes.LastIndex(xmlData, []byte(attachmentContentPlaceholder))
+	if i < 0 {
+		return nil, 0, nil, fmt.Errorf("attachment content placeholder missing from request")
+	}
+	prefix, suffix := xmlData[:i], xmlData[i+len(attachmentContentPlaceholder):]
+
+	pr, pw := io.Pipe()
+	go func() {
+		encoder := base64.NewEncoder(base64.StdEncoding, pw)
+		// One byte more than size is read to detect content longer than the declared size
+		n, err := io.Copy(encoder, io.LimitReader(content, size+1))
+		if err == nil && n != size {
+			err = fmt.Errorf("attachment content does not match its size of %d bytes", size)
+		}
+		if err == nil {
+			err = encoder.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	// The length is known up front, so the request is not sent chunked, which some servers reject
+	length := int64(len(prefix)) + (size+2)/3*4 + int64(len(suffix))
+	payload := io.MultiReader(bytes.NewReader(prefix), pr, bytes.NewReader(suffix))
+	return payload, length, func() { pr.Close() }, nil
+}
+
+// extractAttachmentContent copies the decoded text of the first Content element in r to w
+// and returns the remainder of the document with that element emptied
+func extractAttachmentContent(r io.Reader, w io.Writer) ([]byte, error) {
+	br := bufio.NewReader(r)
+	var doc bytes.Buffer
+
+	success := false
+	for {
+		chunk, err := br.ReadBytes('>')
+		doc.Write(chunk)
+		if err == io.EOF {
+			// No content element, e.g. an error response
+			return doc.Bytes(), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading EWS response body: %w", err)
+		}
+
+		tag := chunk[max(bytes.LastIndexByte(chunk, '<'), 0):]
+		if match := responseClassAttr.FindSubmatch(tag); match != nil {
+			success = string(match[1]) == "Success"
+		}
+		if contentStartTag.Match(tag) && !bytes.HasSuffix(tag, []byte("/>")) {
+			break
+		}
+	}
+
+	if !success {
+		// Nothing is written to w unless the response message has succeeded
+		w = io.Discard
+	}
+
+	if _, err := io.Copy(w, base64.NewDecoder(base64.StdEncoding, &elementTextReader{r: br})); err != nil {
+		return nil, fmt.Errorf("error decoding attachment content: %w", err)
+	}
+
+	rest, err := io.ReadAll(br)
+	if err != nil {
+		return nil, fmt.Errorf("error reading EWS response body: %w", err)
+	}
+	doc.Write(rest)
+
+	return doc.Bytes(), nil
+}
+
+// elementTextReader reads character data up to, but not including, the next '<'
+type elementTextReader struct {
+	r    *bufio.Reader
+	done bool
+}
+
+func (t *elementTextReader) Read(p []byte) (int, error) {
+	if t.done {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for n < len(p) {
+		b, err := t.r.ReadByte()
+		if err == io.EOF {
+			return n, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return n, err
+		}
+		if b == '<' {
+			t.r.UnreadByte()
+			t.done = true
+			break
+		}
+		p[n] = b
+		n++
+	}
+
+	if n == 0 && t.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,113 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// Availability types and the free time engine are shared with the ews package
+type (
+	TimeSlot            = ews.TimeSlot
+	BusyInterval        = ews.BusyInterval
+	AvailabilityOptions = ews.AvailabilityOptions
+	WorkingHours        = ews.WorkingHours
+	WorkingWindow       = ews.WorkingWindow
+)
+
+// BusyIntervals returns the time occupied by the events, e.g. as returned by GetEvents
+func BusyIntervals(events []Event) []BusyInterval {
+	return ews.BusyIntervals(events)
+}
+
+// CheckSlotAvailability checks if a given time slot is available in the target user's calendar,
+// applying the rules of opts: only events whose free/busy status blocks time conflict, including
+// their buffers. It returns true if the slot is available, false and the conflicting items otherwise.
+// A slot that opts rules out otherwise, e.g. outside the working hours, is unavailable without conflicts.
+func (c *ImpersonationClient) CheckSlotAvailability(ctx context.Context, slot TimeSlot, opts AvailabilityOptions, targetUserEmail string) (bool, []CalendarItem, error) {
+	if !opts.Allows(slot) {
+		return false, nil, nil
+	}
+
+	startTime, endTime := opts.SearchRange(slot.Start, slot.End)
+	items, err := c.GetCalendarItems(ctx, startTime, endTime, targetUserEmail)
+	if err != nil {
+		return false, nil, err
+	}
+	events, err := c.ToEvents(items)
+	if err != nil {
+		return false, nil, err
+	}
+
+	available, indexes := opts.CheckSlot(slot, BusyIntervals(events))
+	conflicts := make([]CalendarItem, 0, len(indexes))
+	for _, i := range indexes {
+		conflicts = append(conflicts, items[i])
+	}
+	return available, conflicts, nil
+}
+
+// GetAvailableSlots finds all available time slots of the specified duration within a time range
+// in the target user's calendar, applying the blocking statuses, step, working hours and booking
+// rules of opts
+func (c *ImpersonationClient) GetAvailableSlots(ctx context.Context, startTime, endTime time.Time, slotDuration time.Duration, opts AvailabilityOptions, targetUserEmail string) ([]TimeSlot, error) {
+	from, to := opts.SearchRange(startTime, endTime)
+	events, err := c.GetEvents(ctx, from, to, targetUserEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	return ews.FreeSlots(startTime, endTime, slotDuration, BusyIntervals(events), opts), nil
+}
+
+// GetWorkingHours reads the working hours configured in the target user's calendar options,
+// for use as AvailabilityOptions.WorkingHours
+func (c *ImpersonationClient) GetWorkingHours(ctx context.Context, targetUserEmail string) (*WorkingHours, error) {
+	var responseEnvelope GetUserConfigurationResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetUserConfiguration"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, ews.NewWorkHoursRequest(), &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	respMsg := responseEnvelope.Body.GetUserConfigurationResponse.ResponseMessages.GetUserConfigurationResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error getting working hours: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	return ews.ParseWorkingHours(respMsg.XmlData)
+}
+
+// maxAvailabilityMailboxes is the number of mailboxes the server accepts in one GetUserAvailability request
+const maxAvailabilityMailboxes = 100
+
+// GetUserAvailability returns the free/busy information of the mailboxes between start and end,
+// in the order of emails. The request is made as the target user, who only needs free/busy
+// permission on each mailbox. The server limits the range to 42 days.
+func (c *ImpersonationClient) GetUserAvailability(ctx context.Context, emails []string, start, end time.Time, opts FreeBusyOptions, targetUserEmail string) ([]AttendeeAvailability, error) {
+	start = start.In(c.timeZone)
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetUserAvailability"
+
+	availability := make([]AttendeeAvailability, 0, len(emails))
+	for chunk := range slices.Chunk(emails, maxAvailabilityMailboxes) {
+		var responseEnvelope GetUserAvailabilityResponseEnvelope
+		err := c.doRequest(ctx, soapAction, targetUserEmail, ews.NewUserAvailabilityRequest(chunk, start, end, opts), &responseEnvelope)
+		if err != nil {
+			return nil, err
+		}
+
+		responses := responseEnvelope.Body.GetUserAvailabilityResponse.FreeBusyResponses
+		if len(responses) != len(chunk) {
+			return nil, fmt.Errorf("EWS error getting user availability: expected %d free/busy responses, got %d", len(chunk), len(responses))
+		}
+		for i, response := range responses {
+			availability = append(availability, response.Availability(chunk[i], start, opts))
+		}
+	}
+
+	return availability, nil
+}
@@ -0,0 +1,177 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// defaultBatchSize is the number of items sent per request when no batch size is given
+const defaultBatchSize = 50
+
+// ResponseError is an error the server reported for a single item of a request
+type ResponseError = ews.ResponseError
+
+// err returns the error reported for the item, or nil if it succeeded
+func (m ItemResponseMessage) err() error {
+	if m.ResponseClass == "Success" {
+		return nil
+	}
+	return &ResponseError{ResponseClass: m.ResponseClass, ResponseCode: m.ResponseCode, MessageText: m.MessageText}
+}
+
+// BatchResult is the outcome of one item of a batch operation
+type BatchResult struct {
+	ItemId *ItemId       // ID of the item; nil for deleted and failed items
+	Item   *CalendarItem // Item returned by the server; only GetCalendarItemsByID returns more than its ID
+	Err    error         // *ResponseError if the server rejected the item, or the error of the whole request
+}
+
+// EventChange holds the updates applied to one event by UpdateCalendarEvents
+type EventChange struct {
+	ItemId  ItemId // ID and optional change key of the event
+	Updates EventUpdates
+}
+
+// CreateCalendarEvents creates the events for the target user with one request per batch of batchSize
+// events, or 50 when batchSize is zero. Consecutive events are only sent together when they share Folder.
+// The results are in the order of events.
+func (c *ImpersonationClient) CreateCalendarEvents(ctx context.Context, events []CalendarEvent, sendMeetingInvitations string, batchSize int, targetUserEmail string) ([]BatchResult, error) {
+	for _, event := range events {
+		if err := ews.CheckTimeZones(event.Start, event.End); err != nil {
+			return nil, err
+		}
+	}
+	split := func(i int) bool {
+		return events[i].Folder != events[i-1].Folder
+	}
+
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/CreateItem"
+	return c.runBatches(ctx, len(events), batchSize, split, soapAction, targetUserEmail, func(start, end int) interface{} {
+		request := &CreateEventRequest{
+			SendMeetingInvitations: sendMeetingInvitations,
+			SavedItemFolderId:      events[start].Folder.savedItemFolderId(),
+		}
+		for _, event := range events[start:end] {
+			request.Items.CalendarItem = append(request.Items.CalendarItem, c.newCalendarItem(event))
+		}
+		return request
+	})
+}
+
+// UpdateCalendarEvents applies the changes to events of the target user with one request per batch of
+// batchSize events, or 50 when batchSize is zero. The results are in the order of changes; changes
+// without any updates fail without being sent.
+func (c *ImpersonationClient) UpdateCalendarEvents(ctx context.Context, changes []EventChange, conflictResolution, sendMeetingInvitationsOrCancellations string, batchSize int, targetUserEmail string) ([]BatchResult, error) {
+	results := make([]BatchResult, len(changes))
+
+	var itemChanges []ItemChange
+	var indexes []int // Index in changes of each entry of itemChanges
+	for i, change := range changes {
+		itemChange, err := c.itemChange(change.ItemId, change.Updates)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		itemChanges = append(itemChanges, itemChange)
+		indexes = append(indexes, i)
+	}
+
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/UpdateItem"
+	sent, err := c.runBatches(ctx, len(itemChanges), batchSize, nil, soapAction, targetUserEmail, func(start, end int) interface{} {
+		return &UpdateItemRequest{
+			XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ConflictResolution:     conflictResolution,
+			SendMeetingInvitations: sendMeetingInvitationsOrCancellations,
+			MessageDisposition:     "SaveOnly",
+			ItemChanges:            ItemChanges{ItemChange: itemChanges[start:end]},
+		}
+	})
+	for i, result := range sent {
+		results[indexes[i]] = result
+	}
+
+	return results, err
+}
+
+// DeleteCalendarEvents deletes events of the target user with one request per batch of batchSize events,
+// or 50 when batchSize is zero. The results are in the order of itemIds.
+// deleteType can be "HardDelete", "SoftDelete", "MoveToDeletedItems".
+func (c *ImpersonationClient) DeleteCalendarEvents(ctx context.Context, itemIds []ItemId, deleteType, sendMeetingCancellations string, batchSize int, targetUserEmail string) ([]BatchResult, error) {
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/DeleteItem"
+	return c.runBatches(ctx, len(itemIds), batchSize, nil, soapAction, targetUserEmail, func(start, end int) interface{} {
+		return &DeleteItemRequest{
+			XMLNSm:                   "http://schemas.microsoft.com/exchange/services/2006/messages",
+			DeleteType:               deleteType,
+			SendMeetingCancellations: sendMeetingCancellations,
+			ItemIds:                  ItemIds{ItemId: itemIds[start:end]},
+		}
+	})
+}
+
+// GetCalendarItemsByID retrieves calendar items of the target user by their IDs, including their bodies,
+// with one request per batch of batchSize items, or 50 when batchSize is zero. The results are in the
+// order of itemIds; bodyType selects the format bodies are returned in.
+func (c *ImpersonationClient) GetCalendarItemsByID(ctx context.Context, itemIds []string, bodyType BodyType, batchSize int, targetUserEmail string) ([]BatchResult, error) {
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetItem"
+	return c.runBatches(ctx, len(itemIds), batchSize, nil, soapAction, targetUserEmail, func(start, end int) interface{} {
+		request := &GetItemRequest{
+			XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ItemShape: ItemShape{
+				BaseShape: "AllProperties",
+				BodyType:  bodyType,
+			},
+		}
+		for _, id := range itemIds[start:end] {
+			request.ItemIds.ItemId = append(request.ItemIds.ItemId, ItemId{Id: id})
+		}
+		return request
+	})
+}
+
+// runBatches sends n items in requests of at most batchSize items built by build, which receives the
+// range of items to include. split reports whether item i cannot share a request with item i-1.
+// The server returns one response message per item, in request order. If a request fails, its error
+// is recorded for the items of that and all later batches, which are not sent, and returned.
+func (c *ImpersonationClient) runBatches(ctx context.Context, n, batchSize int, split func(i int) bool, soapAction, targetUserEmail string, build func(start, end int) interface{}) ([]BatchResult, error) {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	results := make([]BatchResult, n)
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && end-start < batchSize && (split == nil || !split(end)) {
+			end++
+		}
+
+		var responseEnvelope ItemResponseEnvelope
+		err := c.doRequest(ctx, soapAction, targetUserEmail, build(start, end), &responseEnvelope)
+		respMsgs := responseEnvelope.Body.Response.ResponseMessages.Messages
+		if err == nil && len(respMsgs) != end-start {
+			err = fmt.Errorf("expected %d response messages, got %d", end-start, len(respMsgs))
+		}
+		if err != nil {
+			for i := start; i < n; i++ {
+				results[i].Err = err
+			}
+			return results, err
+		}
+
+		for i, respMsg := range respMsgs {
+			result := &results[start+i]
+			if result.Err = respMsg.err(); result.Err != nil {
+				continue
+			}
+			result.ItemId = respMsg.Items.firstItemId()
+			if len(respMsg.Items.CalendarItem) > 0 {
+				result.Item = &respMsg.Items.CalendarItem[0]
+			}
+		}
+
+		start = end
+	}
+
+	return results, nil
+}
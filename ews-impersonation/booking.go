@@ -0,0 +1,72 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// SlotConflictError is returned by BookSlot when the slot is not available
+type SlotConflictError = ews.SlotConflictError
+
+// BookSlot creates the event in the target user's calendar if its time is available according to
+// opts, and makes sure that of several bookings racing for the same time at most one is kept. After
+// creating the event the calendar is read again and checked with ews.VerifyBooking; if the event lost
+// the slot, it is deleted without sending cancellations and a *SlotConflictError with RolledBack set
+// is returned. Invitations are sent when the event is created, so a rolled back booking leaves them
+// behind unless sendMeetingInvitations is "SendToNone".
+// If the calendar cannot be read again, the ID of the unverified event is returned with the error.
+func (c *ImpersonationClient) BookSlot(ctx context.Context, event CalendarEvent, sendMeetingInvitations string, opts AvailabilityOptions, targetUserEmail string) (*ItemId, error) {
+	slot := TimeSlot{Start: event.Start, End: event.End}
+	if opts.Now.IsZero() {
+		// Check and re-check the notice and lookahead against the same time
+		opts.Now = time.Now()
+	}
+	if !opts.Allows(slot) {
+		return nil, &SlotConflictError{Slot: slot}
+	}
+
+	items, busy, err := c.slotBusyTime(ctx, event.Folder, slot, opts, targetUserEmail)
+	if err != nil {
+		return nil, err
+	}
+	if err := ews.CheckBooking(slot, opts, items, busy); err != nil {
+		return nil, err
+	}
+
+	itemID, err := c.CreateCalendarEvent(ctx, event, sendMeetingInvitations, targetUserEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	items, busy, err = c.slotBusyTime(ctx, event.Folder, slot, opts, targetUserEmail)
+	if err != nil {
+		return itemID, fmt.Errorf("error verifying booking: %w", err)
+	}
+	conflictErr := ews.VerifyBooking(slot, opts, items, busy, itemID.Id)
+	if conflictErr == nil {
+		return itemID, nil
+	}
+
+	if err := c.DeleteCalendarEvent(ctx, itemID.Id, "", "HardDelete", "SendToNone", targetUserEmail); err != nil {
+		return nil, errors.Join(conflictErr, fmt.Errorf("error rolling back booking: %w", err))
+	}
+	return nil, conflictErr
+}
+
+// slotBusyTime reads the items of the folder that can conflict with the slot and their busy time
+func (c *ImpersonationClient) slotBusyTime(ctx context.Context, folder CalendarFolder, slot TimeSlot, opts AvailabilityOptions, targetUserEmail string) ([]CalendarItem, []BusyInterval, error) {
+	from, to := opts.SearchRange(slot.Start, slot.End)
+	items, err := c.GetCalendarItemsInFolder(ctx, folder, from, to, targetUserEmail)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, err := c.ToEvents(items)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, BusyIntervals(events), nil
+}
@@ -0,0 +1,188 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+const (
+	// defaultCalendarPageSize stays below the 1000 item FindItem limit of the default throttling policy
+	defaultCalendarPageSize = 500
+	// defaultCalendarWindow stays well below the two year limit EWS puts on a single CalendarView
+	defaultCalendarWindow = 90 * 24 * time.Hour
+)
+
+// ErrIncompleteCalendarView is returned when the server did not return every item in the requested range
+var ErrIncompleteCalendarView = errors.New("calendar view results are incomplete")
+
+// CalendarViewOptions controls how a calendar range is split into FindItem requests
+type CalendarViewOptions struct {
+	// PageSize is the maximum number of items requested per FindItem call. Defaults to 500.
+	PageSize int
+	// WindowSize is the longest range covered by a single CalendarView. Defaults to 90 days.
+	WindowSize time.Duration
+	// Folder is the calendar to read. Defaults to the target user's calendar.
+	Folder CalendarFolder
+	// Shape selects the properties returned for each item. Defaults to all properties.
+	Shape Shape
+}
+
+func (o CalendarViewOptions) withDefaults() CalendarViewOptions {
+	if o.PageSize <= 0 {
+		o.PageSize = defaultCalendarPageSize
+	}
+	if o.WindowSize <= 0 {
+		o.WindowSize = defaultCalendarWindow
+	}
+	// Paging continues from the start of the last item, so the start has to be returned
+	if o.Shape.BaseShape == BaseShapeIdOnly && !slices.Contains(o.Shape.AdditionalProperties, PropertyPath(ews.FieldStart)) {
+		o.Shape.AdditionalProperties = append(slices.Clip(o.Shape.AdditionalProperties), ews.FieldStart)
+	}
+	return o
+}
+
+// CalendarItemsResult holds the items found in a calendar range
+type CalendarItemsResult struct {
+	Items []CalendarItem
+	// Complete is false when the server stopped returning items before the end of the range,
+	// e.g. because more items start at the same instant than fit in one page
+	Complete bool
+}
+
+// FindCalendarItems retrieves every calendar item of the target user between the specified dates.
+// Long ranges are split into windows and each window is paged with MaxEntriesReturned; items
+// returned by more than one request, such as events spanning a window boundary, are only included once.
+func (c *ImpersonationClient) FindCalendarItems(ctx context.Context, startDate, endDate time.Time, opts CalendarViewOptions, targetUserEmail string) (*CalendarItemsResult, error) {
+	result := &CalendarItemsResult{}
+	complete, err := c.walkCalendarView(ctx, startDate, endDate, opts, targetUserEmail, func(item CalendarItem) bool {
+		result.Items = append(result.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Complete = complete
+
+	return result, nil
+}
+
+// CalendarItemsSeq iterates over the calendar items of the target user between the specified dates like
+// FindCalendarItems, but fetches each page only when the previous one has been consumed. Iteration stops
+// at the first error, which is ErrIncompleteCalendarView if the server did not return every item.
+func (c *ImpersonationClient) CalendarItemsSeq(ctx context.Context, startDate, endDate time.Time, opts CalendarViewOptions, targetUserEmail string) iter.Seq2[CalendarItem, error] {
+	return func(yield func(CalendarItem, error) bool) {
+		stopped := false
+		complete, err := c.walkCalendarView(ctx, startDate, endDate, opts, targetUserEmail, func(item CalendarItem) bool {
+			stopped = !yield(item, nil)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+		if err != nil {
+			yield(CalendarItem{}, err)
+		} else if !complete {
+			yield(CalendarItem{}, ErrIncompleteCalendarView)
+		}
+	}
+}
+
+// walkCalendarView pages through the range window by window, passing each item not seen before to
+// yield until it returns false. Each page continues from the start of the last item returned, as
+// CalendarView has no offset. It reports false if the server did not return every item.
+func (c *ImpersonationClient) walkCalendarView(ctx context.Context, startDate, endDate time.Time, opts CalendarViewOptions, targetUserEmail string, yield func(CalendarItem) bool) (bool, error) {
+	opts = opts.withDefaults()
+	seen := make(map[string]bool)
+
+	for windowStart := startDate; windowStart.Before(endDate); {
+		windowEnd := windowStart.Add(opts.WindowSize)
+		if windowEnd.After(endDate) {
+			windowEnd = endDate
+		}
+
+		for pageStart := windowStart; ; {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+
+			items, includesLast, err := c.findCalendarPage(ctx, pageStart, windowEnd, opts, targetUserEmail)
+			if err != nil {
+				return false, err
+			}
+
+			for _, item := range items {
+				if seen[item.ItemId.Id] {
+					continue
+				}
+				seen[item.ItemId.Id] = true
+				if !yield(item) {
+					return true, nil
+				}
+			}
+
+			if includesLast || len(items) == 0 {
+				break
+			}
+
+			next, err := c.ParseDateTime(items[len(items)-1].Start)
+			if err != nil {
+				return false, err
+			}
+			if !next.After(pageStart) {
+				// A full page of items overlaps pageStart, so continuing would return the same page again
+				return false, nil
+			}
+			pageStart = next
+		}
+
+		windowStart = windowEnd
+	}
+
+	return true, nil
+}
+
+// findCalendarPage issues a single FindItem CalendarView request and reports whether
+// the returned items include the last item in the range
+func (c *ImpersonationClient) findCalendarPage(ctx context.Context, start, end time.Time, opts CalendarViewOptions, targetUserEmail string) ([]CalendarItem, bool, error) {
+	request := &FindItemRequest{
+		XMLNSm:    "http://schemas.microsoft.com/exchange/services/2006/messages",
+		Traversal: "Shallow",
+		ItemShape: itemShape(opts.Shape),
+		CalendarView: &CalendarView{
+			MaxEntriesReturned: opts.PageSize,
+			StartDate:          c.FormatDateWithTZ(start),
+			EndDate:            c.FormatDateWithTZ(end),
+		},
+		ParentFolderIds: opts.Folder.parentFolderIds(),
+	}
+
+	var responseEnvelope ResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/FindItem"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return nil, false, err
+	}
+
+	respMsg := responseEnvelope.Body.FindItemResponse.ResponseMessages.FindItemResponseMessage
+	if respMsg.ResponseCode != "NoError" {
+		return nil, false, fmt.Errorf("EWS error in GetCalendarItems: %s", respMsg.ResponseCode)
+	}
+
+	return respMsg.RootFolder.Items.CalendarItem, respMsg.RootFolder.includesLast(opts.PageSize), nil
+}
+
+// includesLast reports whether a CalendarView page reached the end of its range. Servers that
+// omit IncludesLastItemInRange are assumed to have done so when the page is not full.
+func (r RootFolder) includesLast(pageSize int) bool {
+	if r.IncludesLastItemInRange != nil {
+		return *r.IncludesLastItemInRange
+	}
+	return len(r.Items.CalendarItem) < pageSize
+}
@@ -8,12 +8,14 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/slav123/ews-workmail/ews"
 )
 
 const (
@@ -30,8 +32,9 @@ type ImpersonationClient struct {
 	impersonationRoleID string
 	ewsEndpoint         string
 
-	httpClient *http.Client
-	timeZone   *time.Location
+	httpClient    *http.Client
+	timeZone      *time.Location
+	serverVersion string
 
 	awsWorkMailClient *workmail.Client
 
@@ -64,6 +67,7 @@ func NewImpersonationClientWithAWSConfig(cfg aws.Config, awsRegion, workmailOrgI
 			Timeout: 30 * time.Second,
 		},
 		timeZone:          time.Local, // Default to local timezone
+		serverVersion:     defaultEWSVersion,
 		awsWorkMailClient: wmClient,
 	}
 
@@ -105,20 +109,15 @@ func (c *ImpersonationClient) getToken(ctx context.Context) (string, error) {
 	return *c.currentToken, nil
 }
 
-// doRequest performs the actual EWS request.
-func (c *ImpersonationClient) doRequest(ctx context.Context, soapAction, targetUserEmail string, requestBody interface{}, responseBody interface{}) error {
-	token, err := c.getToken(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get EWS token: %w", err)
-	}
-
+// marshalEnvelope wraps the request body in a SOAP envelope impersonating the target user and converts it to XML.
+func (c *ImpersonationClient) marshalEnvelope(targetUserEmail string, requestBody interface{}) ([]byte, error) {
 	envelope := Envelope{
 		XMLNS:  "http://schemas.xmlsoap.org/soap/envelope/",
 		XMLNSt: "http://schemas.microsoft.com/exchange/services/2006/types",
 		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
 		Header: Header{
 			ServerVersionInfo: ServerVersionInfo{
-				Version: defaultEWSVersion,
+				Version: c.serverVersion,
 			},
 			ExchangeImpersonation: &ExchangeImpersonationType{
 				ConnectingSID: ConnectingSIDType{
@@ -139,13 +138,37 @@ func (c *ImpersonationClient) doRequest(ctx context.Context, soapAction, targetU
 		envelope.Body.DeleteItem = r
 	case *UpdateItemRequest:
 		envelope.Body.UpdateItem = r
+	case *GetItemRequest:
+		envelope.Body.GetItem = r
+	case *CreateAttachmentRequest:
+		envelope.Body.CreateAttachment = r
+	case *GetAttachmentRequest:
+		envelope.Body.GetAttachment = r
+	case *DeleteAttachmentRequest:
+		envelope.Body.DeleteAttachment = r
+	case *GetServerTimeZonesRequest:
+		envelope.Body.GetServerTimeZones = r
+	case *FindFolderRequest:
+		envelope.Body.FindFolder = r
+	case *MoveItemRequest:
+		envelope.Body.MoveItem = r
+	case *CopyItemRequest:
+		envelope.Body.CopyItem = r
+	case *GetUserConfigurationRequest:
+		envelope.Body.GetUserConfiguration = r
+	case *GetUserAvailabilityRequest:
+		envelope.Body.GetUserAvailability = r
+	case *GetRoomListsRequest:
+		envelope.Body.GetRoomLists = r
+	case *GetRoomsRequest:
+		envelope.Body.GetRooms = r
 	default:
-		return fmt.Errorf("unsupported request body type: %T", requestBody)
+		return nil, fmt.Errorf("unsupported request body type: %T", requestBody)
 	}
 
 	xmlData, err := xml.MarshalIndent(envelope, "", "  ")
 	if err != nil {
-		return fmt.Errorf("error marshalling EWS request: %w", err)
+		return nil, fmt.Errorf("error marshalling EWS request: %w", err)
 	}
 
 	// Log the XML for UpdateItem requests for debugging
@@ -153,10 +176,22 @@ func (c *ImpersonationClient) doRequest(ctx context.Context, soapAction, targetU
 	// 	log.Printf("DEBUG: EWS UpdateItem Request XML:\n%s\n", string(xmlData))
 	// }
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.ewsEndpoint, bytes.NewReader(xmlData))
+	return xmlData, nil
+}
+
+// post sends a SOAP payload to the EWS endpoint and returns the response once its status has been checked.
+// The caller must close the response body.
+func (c *ImpersonationClient) post(ctx context.Context, soapAction string, payload io.Reader, contentLength int64) (*http.Response, error) {
+	token, err := c.getToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EWS token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.ewsEndpoint, payload)
 	if err != nil {
-		return fmt.Errorf("error creating EWS HTTP request: %w", err)
+		return nil, fmt.Errorf("error creating EWS HTTP request: %w", err)
 	}
+	req.ContentLength = contentLength
 
 	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
 	req.Header.Set("SOAPAction", soapAction)
@@ -164,7 +199,28 @@ func (c *ImpersonationClient) doRequest(ctx context.Context, soapAction, targetU
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("error sending EWS request: %w", err)
+		return nil, fmt.Errorf("error sending EWS request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("EWS request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
+
+// doRequest performs the actual EWS request.
+func (c *ImpersonationClient) doRequest(ctx context.Context, soapAction, targetUserEmail string, requestBody interface{}, responseBody interface{}) error {
+	xmlData, err := c.marshalEnvelope(targetUserEmail, requestBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.post(ctx, soapAction, bytes.NewReader(xmlData), int64(len(xmlData)))
+	if err != nil {
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -173,10 +229,6 @@ func (c *ImpersonationClient) doRequest(ctx context.Context, soapAction, targetU
 		return fmt.Errorf("error reading EWS response body: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("EWS request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
 	if err := xml.Unmarshal(bodyBytes, responseBody); err != nil {
 		return fmt.Errorf("error unmarshalling EWS response: %w. Response body: %s", err, string(bodyBytes))
 	}
@@ -223,89 +275,112 @@ func (c *ImpersonationClient) SetTimezone(timezone string) error {
 	return nil
 }
 
+// SetServerVersion changes the EWS schema version requested, e.g. "Exchange2007_SP1".
+// Exchange 2007 versions send MeetingTimeZone instead of StartTimeZone/EndTimeZone.
+func (c *ImpersonationClient) SetServerVersion(version string) {
+	c.serverVersion = version
+}
+
+// formatDateInLocation formats a time.Time with the offset of its own location
+func formatDateInLocation(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05-07:00")
+}
+
+// GetServerTimeZones returns the time zones known to the server. When ids is empty all
+// zones are returned; fullData also requests the periods of each zone.
+func (c *ImpersonationClient) GetServerTimeZones(ctx context.Context, ids []string, fullData bool, targetUserEmail string) ([]ServerTimeZone, error) {
+	request := &GetServerTimeZonesRequest{
+		XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
+		ReturnFullTimeZoneData: fullData,
+	}
+	if len(ids) > 0 {
+		request.Ids = &TimeZoneIds{Id: ids}
+	}
+
+	var responseEnvelope GetServerTimeZonesResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetServerTimeZones"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	respMsg := responseEnvelope.Body.GetServerTimeZonesResponse.ResponseMessages.GetServerTimeZonesResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error getting server time zones: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	return respMsg.TimeZoneDefinitions, nil
+}
+
 // GetCalendarItems retrieves calendar items for the target user between the specified dates.
+// Long ranges are fetched in several requests; ErrIncompleteCalendarView is returned if the server
+// did not return every item. Use FindCalendarItems to control paging or to accept partial results.
 func (c *ImpersonationClient) GetCalendarItems(ctx context.Context, startDate, endDate time.Time, targetUserEmail string) ([]CalendarItem, error) {
-	startDateStr := c.FormatDateWithTZ(startDate)
-	endDateStr := c.FormatDateWithTZ(endDate)
+	return c.GetCalendarItemsInFolder(ctx, CalendarFolder{}, startDate, endDate, targetUserEmail)
+}
+
+// GetCalendarItemsInFolder retrieves calendar items between the specified dates from the given
+// calendar of the target user, such as a project calendar or another mailbox's calendar
+func (c *ImpersonationClient) GetCalendarItemsInFolder(ctx context.Context, folder CalendarFolder, startDate, endDate time.Time, targetUserEmail string) ([]CalendarItem, error) {
+	result, err := c.FindCalendarItems(ctx, startDate, endDate, CalendarViewOptions{Folder: folder}, targetUserEmail)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Complete {
+		return nil, fmt.Errorf("%w: %d items found between %s and %s", ErrIncompleteCalendarView,
+			len(result.Items), c.FormatDateWithTZ(startDate), c.FormatDateWithTZ(endDate))
+	}
+
+	return result.Items, nil
+}
 
-	request := &FindItemRequest{
-		XMLNSm:    "http://schemas.microsoft.com/exchange/services/2006/messages",
-		Traversal: "Shallow",
+// GetCalendarItem retrieves a single calendar item for the target user by its ID, including its body.
+// bodyType selects the format the body is returned in; an empty value lets the server decide.
+func (c *ImpersonationClient) GetCalendarItem(ctx context.Context, itemId string, bodyType BodyType, targetUserEmail string) (*CalendarItem, error) {
+	request := &GetItemRequest{
+		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
 		ItemShape: ItemShape{
-			BaseShape: "AllProperties", // Or "IdOnly", "Default"
-		},
-		CalendarView: CalendarView{
-			StartDate: startDateStr,
-			EndDate:   endDateStr,
+			BaseShape: "AllProperties",
+			BodyType:  bodyType,
 		},
-		ParentFolderIds: ParentFolderIds{
-			DistinguishedFolderId: DistinguishedFolderId{
-				Id: "calendar",
-			},
+		ItemIds: ItemIds{
+			ItemId: []ItemId{{Id: itemId}},
 		},
 	}
 
-	var responseEnvelope ResponseEnvelope
-	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/FindItem"
+	var responseEnvelope GetItemResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetItem"
 
 	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
 	if err != nil {
 		return nil, err
 	}
 
-	respMsg := responseEnvelope.Body.FindItemResponse.ResponseMessages.FindItemResponseMessage
-	if respMsg.ResponseCode != "NoError" {
-		return nil, fmt.Errorf("EWS error in GetCalendarItems: %s", respMsg.ResponseCode)
+	respMsg := responseEnvelope.Body.GetItemResponse.ResponseMessages.GetItemResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error getting event: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	if len(respMsg.Items.CalendarItem) == 0 {
+		return nil, fmt.Errorf("calendar item not found in EWS response")
 	}
 
-	// Convert internal CalendarItem types to a more usable format if needed, or return directly.
-	// For now, returning the direct parsed items.
-	// Note: The CalendarItem struct in types.go will need Start/End parsed to time.Time by the caller or a helper.
-	return respMsg.RootFolder.Items.CalendarItem, nil
+	return &respMsg.Items.CalendarItem[0], nil
 }
 
 // CreateCalendarEvent creates a new calendar event for the target user.
 // sendMeetingInvitations can be "SendToNone", "SendOnlyToAll", "SendToAllAndSaveCopy".
 func (c *ImpersonationClient) CreateCalendarEvent(ctx context.Context, event CalendarEvent, sendMeetingInvitations string, targetUserEmail string) (*ItemId, error) {
-	xmlNSt := "http://schemas.microsoft.com/exchange/services/2006/types"
-
-	calItem := CreateEventCalendarItem{
-		XMLNSt:          xmlNSt,
-		Subject:         event.Subject,
-		Body:            ItemBody{BodyType: "Text", Content: event.Body}, // Assuming Text body type
-		ReminderIsSet:   true,                                            // Default, can be made configurable
-		ReminderMinutes: 15,                                              // Default, can be made configurable
-		Start:           c.FormatDateWithTZ(event.Start),
-		End:             c.FormatDateWithTZ(event.End),
-		IsAllDayEvent:   event.IsAllDay,
-		LegacyFreeBusy:  Busy, // Default, can be made configurable (e.g. Free, Tentative, OOF)
-		Location:        event.Location,
-	}
-
-	if len(event.RequiredAttendees) > 0 {
-		calItem.RequiredAttendees = &RequiredAttendees{}
-		for _, ra := range event.RequiredAttendees {
-			calItem.RequiredAttendees.Attendees = append(calItem.RequiredAttendees.Attendees, AttendeeType{
-				Mailbox: EmailAddress{Name: ra.Name, EmailAddress: ra.Email, RoutingType: "SMTP"},
-			})
-		}
-	}
-	if len(event.OptionalAttendees) > 0 {
-		calItem.OptionalAttendees = &OptionalAttendees{}
-		for _, oa := range event.OptionalAttendees {
-			calItem.OptionalAttendees.Attendees = append(calItem.OptionalAttendees.Attendees, AttendeeType{
-				Mailbox: EmailAddress{Name: oa.Name, EmailAddress: oa.Email, RoutingType: "SMTP"},
-			})
-		}
+	if err := ews.CheckTimeZones(event.Start, event.End); err != nil {
+		return nil, err
 	}
 
 	request := &CreateEventRequest{
 		SendMeetingInvitations: sendMeetingInvitations,
-		SavedItemFolderId: SavedItemFolderId{
-			DistinguishedFolderId: DistinguishedFolderId{Id: "calendar"},
-		},
+		SavedItemFolderId:      event.Folder.savedItemFolderId(),
 		Items: CreateEventItems{
-			CalendarItem: calItem,
+			CalendarItem: []CreateEventCalendarItem{c.newCalendarItem(event)},
 		},
 	}
 
@@ -336,50 +411,208 @@ func (c *ImpersonationClient) CreateCalendarEvent(ctx context.Context, event Cal
 	return nil, fmt.Errorf("created item ID not found in EWS response")
 }
 
+// newCalendarItem builds the CalendarItem element that creates the event
+func (c *ImpersonationClient) newCalendarItem(event CalendarEvent) CreateEventCalendarItem {
+	xmlNSt := "http://schemas.microsoft.com/exchange/services/2006/types"
+
+	reminderIsSet, reminderMinutes := event.reminder()
+
+	calItem := CreateEventCalendarItem{
+		XMLNSt:           xmlNSt,
+		Subject:          event.Subject,
+		Sensitivity:      event.Sensitivity,
+		Body:             itemBody(event.BodyType, event.Body),
+		Categories:       categoriesOf(event.Categories),
+		Importance:       event.Importance,
+		ReminderIsSet:    reminderIsSet,
+		ReminderMinutes:  reminderMinutes,
+		ExtendedProperty: event.ExtendedProperties,
+		Start:            formatDateInLocation(event.Start),
+		End:              formatDateInLocation(event.End),
+		IsAllDayEvent:    event.IsAllDay,
+		LegacyFreeBusy:   event.freeBusy(),
+		Location:         event.Location,
+	}
+
+	// Send the time zones of the event so it lands at the right wall clock time
+	// regardless of the mailbox's own time zone
+	if strings.HasPrefix(c.serverVersion, "Exchange2007") {
+		meetingTimeZone := ews.NewMeetingTimeZone(event.Start)
+		calItem.MeetingTimeZone = &meetingTimeZone
+	} else {
+		startTimeZone := ews.NewTimeZoneDefinition(event.Start)
+		endTimeZone := ews.NewTimeZoneDefinition(event.End)
+		calItem.StartTimeZone = &startTimeZone
+		calItem.EndTimeZone = &endTimeZone
+	}
+
+	if len(event.RequiredAttendees) > 0 {
+		calItem.RequiredAttendees = &RequiredAttendees{}
+		for _, ra := range event.RequiredAttendees {
+			calItem.RequiredAttendees.Attendees = append(calItem.RequiredAttendees.Attendees, AttendeeType{
+				Mailbox: EmailAddress{Name: ra.Name, EmailAddress: ra.Email, RoutingType: "SMTP"},
+			})
+		}
+	}
+	if len(event.OptionalAttendees) > 0 {
+		calItem.OptionalAttendees = &OptionalAttendees{}
+		for _, oa := range event.OptionalAttendees {
+			calItem.OptionalAttendees.Attendees = append(calItem.OptionalAttendees.Attendees, AttendeeType{
+				Mailbox: EmailAddress{Name: oa.Name, EmailAddress: oa.Email, RoutingType: "SMTP"},
+			})
+		}
+	}
+
+	return calItem
+}
+
 // UpdateCalendarEvent updates an existing calendar event for the target user.
 // conflictResolution can be "NeverOverwrite", "AutoResolve", "AlwaysOverwrite".
 // sendMeetingInvitationsOrCancellations can be "SendToNone", "SendOnlyToChanged", "SendOnlyToAll", "SendToAllAndSaveCopy", "SendToChangedAndSaveCopy".
 func (c *ImpersonationClient) UpdateCalendarEvent(ctx context.Context, itemId string, changeKey string, updates EventUpdates, conflictResolution, sendMeetingInvitationsOrCancellations, targetUserEmail string) error {
+	change, err := c.itemChange(ItemId{Id: itemId, ChangeKey: changeKey}, updates)
+	if err != nil {
+		return err
+	}
+
+	request := &UpdateItemRequest{
+		XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
+		ConflictResolution:     conflictResolution,
+		SendMeetingInvitations: sendMeetingInvitationsOrCancellations,
+		MessageDisposition:     "SaveOnly",
+		ItemChanges: ItemChanges{
+			ItemChange: []ItemChange{change},
+		},
+	}
+
+	var responseEnvelope UpdateItemResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/UpdateItem"
+
+	err = c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return err
+	}
+
+	respMsg := responseEnvelope.Body.UpdateItemResponse.ResponseMessages.UpdateItemResponseMessage
+	if respMsg.ResponseClass != "Success" {
+		return fmt.Errorf("error updating event: %w", &ResponseError{ResponseClass: respMsg.ResponseClass, ResponseCode: respMsg.ResponseCode, MessageText: respMsg.MessageText})
+	}
+
+	return nil
+}
+
+// itemChange builds the ItemChange that applies updates to the item
+func (c *ImpersonationClient) itemChange(itemId ItemId, updates EventUpdates) (ItemChange, error) {
 	var itemChanges []SetItemField
 
+	for _, t := range []*time.Time{updates.Start, updates.End} {
+		if t == nil {
+			continue
+		}
+		if err := ews.CheckTimeZones(*t); err != nil {
+			return ItemChange{}, err
+		}
+	}
+
 	if updates.Subject != nil {
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "item:Subject"},
+			FieldURI:     &FieldURI{FieldURI: "item:Subject"},
 			CalendarItem: UpdateCalendarItem{Subject: updates.Subject},
 		})
 	}
 	if updates.Body != nil {
+		body := itemBody(updates.BodyType, *updates.Body)
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "item:Body"},
-			CalendarItem: UpdateCalendarItem{Body: &ItemBody{BodyType: "Text", Content: *updates.Body}},
+			FieldURI:     &FieldURI{FieldURI: "item:Body"},
+			CalendarItem: UpdateCalendarItem{Body: &body},
 		})
 	}
 	if updates.Start != nil {
-		startStr := c.FormatDateWithTZ(*updates.Start)
+		startStr := formatDateInLocation(*updates.Start)
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "calendar:Start"},
+			FieldURI:     &FieldURI{FieldURI: "calendar:Start"},
 			CalendarItem: UpdateCalendarItem{Start: &startStr},
 		})
 	}
 	if updates.End != nil {
-		endStr := c.FormatDateWithTZ(*updates.End)
+		endStr := formatDateInLocation(*updates.End)
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "calendar:End"},
+			FieldURI:     &FieldURI{FieldURI: "calendar:End"},
 			CalendarItem: UpdateCalendarItem{End: &endStr},
 		})
 	}
+	if strings.HasPrefix(c.serverVersion, "Exchange2007") {
+		if updates.Start != nil {
+			meetingTimeZone := ews.NewMeetingTimeZone(*updates.Start)
+			itemChanges = append(itemChanges, SetItemField{
+				FieldURI:     &FieldURI{FieldURI: "calendar:MeetingTimeZone"},
+				CalendarItem: UpdateCalendarItem{MeetingTimeZone: &meetingTimeZone},
+			})
+		}
+	} else {
+		if updates.Start != nil {
+			startTimeZone := ews.NewTimeZoneDefinition(*updates.Start)
+			itemChanges = append(itemChanges, SetItemField{
+				FieldURI:     &FieldURI{FieldURI: "calendar:StartTimeZone"},
+				CalendarItem: UpdateCalendarItem{StartTimeZone: &startTimeZone},
+			})
+		}
+		if updates.End != nil {
+			endTimeZone := ews.NewTimeZoneDefinition(*updates.End)
+			itemChanges = append(itemChanges, SetItemField{
+				FieldURI:     &FieldURI{FieldURI: "calendar:EndTimeZone"},
+				CalendarItem: UpdateCalendarItem{EndTimeZone: &endTimeZone},
+			})
+		}
+	}
+	if updates.IsAllDay != nil {
+		itemChanges = append(itemChanges, SetItemField{
+			FieldURI:     &FieldURI{FieldURI: "calendar:IsAllDayEvent"},
+			CalendarItem: UpdateCalendarItem{IsAllDayEvent: updates.IsAllDay},
+		})
+	}
 	if updates.Location != nil {
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "calendar:Location"},
+			FieldURI:     &FieldURI{FieldURI: "calendar:Location"},
 			CalendarItem: UpdateCalendarItem{Location: updates.Location},
 		})
 	}
 	if updates.LegacyFreeBusy != nil {
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "calendar:LegacyFreeBusyStatus"},
+			FieldURI:     &FieldURI{FieldURI: "calendar:LegacyFreeBusyStatus"},
 			CalendarItem: UpdateCalendarItem{LegacyFreeBusy: updates.LegacyFreeBusy},
 		})
 	}
+	if updates.Sensitivity != nil {
+		itemChanges = append(itemChanges, SetItemField{
+			FieldURI:     &FieldURI{FieldURI: "item:Sensitivity"},
+			CalendarItem: UpdateCalendarItem{Sensitivity: updates.Sensitivity},
+		})
+	}
+	if updates.Importance != nil {
+		itemChanges = append(itemChanges, SetItemField{
+			FieldURI:     &FieldURI{FieldURI: "item:Importance"},
+			CalendarItem: UpdateCalendarItem{Importance: updates.Importance},
+		})
+	}
+	if len(updates.Categories) > 0 {
+		itemChanges = append(itemChanges, SetItemField{
+			FieldURI:     &FieldURI{FieldURI: "item:Categories"},
+			CalendarItem: UpdateCalendarItem{Categories: categoriesOf(updates.Categories)},
+		})
+	}
+	if updates.ReminderIsSet != nil {
+		itemChanges = append(itemChanges, SetItemField{
+			FieldURI:     &FieldURI{FieldURI: "item:ReminderIsSet"},
+			CalendarItem: UpdateCalendarItem{ReminderIsSet: updates.ReminderIsSet},
+		})
+	}
+	if updates.ReminderMinutes != nil {
+		itemChanges = append(itemChanges, SetItemField{
+			FieldURI:     &FieldURI{FieldURI: "item:ReminderMinutesBeforeStart"},
+			CalendarItem: UpdateCalendarItem{ReminderMinutes: updates.ReminderMinutes},
+		})
+	}
 
 	if len(updates.RequiredAttendees) > 0 {
 		ra := &RequiredAttendees{}
@@ -387,7 +620,7 @@ func (c *ImpersonationClient) UpdateCalendarEvent(ctx context.Context, itemId st
 			ra.Attendees = append(ra.Attendees, AttendeeType{Mailbox: EmailAddress{Name: attendee.Name, EmailAddress: attendee.Email, RoutingType: "SMTP"}})
 		}
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "calendar:RequiredAttendees"},
+			FieldURI:     &FieldURI{FieldURI: "calendar:RequiredAttendees"},
 			CalendarItem: UpdateCalendarItem{RequiredAttendees: ra},
 		})
 	}
@@ -397,42 +630,31 @@ func (c *ImpersonationClient) UpdateCalendarEvent(ctx context.Context, itemId st
 			oa.Attendees = append(oa.Attendees, AttendeeType{Mailbox: EmailAddress{Name: attendee.Name, EmailAddress: attendee.Email, RoutingType: "SMTP"}})
 		}
 		itemChanges = append(itemChanges, SetItemField{
-			FieldURI:     FieldURI{FieldURI: "calendar:OptionalAttendees"},
+			FieldURI:     &FieldURI{FieldURI: "calendar:OptionalAttendees"},
 			CalendarItem: UpdateCalendarItem{OptionalAttendees: oa},
 		})
 	}
 
-	if len(itemChanges) == 0 {
-		return fmt.Errorf("no updates provided for calendar event")
-	}
-
-	request := &UpdateItemRequest{
-		XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
-		ConflictResolution:     conflictResolution,
-		SendMeetingInvitations: sendMeetingInvitationsOrCancellations,
-		MessageDisposition:     "SaveOnly",
-		ItemChanges: ItemChanges{
-			ItemChange: ItemChange{
-				ItemId:  ItemId{Id: itemId, ChangeKey: changeKey},
-				Updates: Updates{SetItemField: itemChanges},
-			},
-		},
+	for _, property := range updates.ExtendedProperties {
+		path := property.ExtendedFieldURI
+		itemChanges = append(itemChanges, SetItemField{
+			ExtendedFieldURI: &path,
+			CalendarItem:     UpdateCalendarItem{ExtendedProperty: []ExtendedProperty{property}},
+		})
 	}
-
-	var responseEnvelope UpdateItemResponseEnvelope
-	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/UpdateItem"
-
-	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
-	if err != nil {
-		return err
+	var deleteFields []DeleteItemField
+	for _, path := range updates.DeleteExtendedProperties {
+		deleteFields = append(deleteFields, DeleteItemField{ExtendedFieldURI: &path})
 	}
 
-	respMsg := responseEnvelope.Body.UpdateItemResponse.ResponseMessages.UpdateItemResponseMessage
-	if respMsg.ResponseClass != "Success" {
-		return fmt.Errorf("EWS error updating event: %s. Code: %s", respMsg.ResponseClass, respMsg.ResponseCode)
+	if len(itemChanges) == 0 && len(deleteFields) == 0 {
+		return ItemChange{}, fmt.Errorf("no updates provided for calendar event")
 	}
 
-	return nil
+	return ItemChange{
+		ItemId:  itemId,
+		Updates: Updates{SetItemField: itemChanges, DeleteItemField: deleteFields},
+	}, nil
 }
 
 // DeleteCalendarEvent deletes a calendar event for the target user.
@@ -443,7 +665,7 @@ func (c *ImpersonationClient) DeleteCalendarEvent(ctx context.Context, itemId st
 		XMLNSm:                   "http://schemas.microsoft.com/exchange/services/2006/messages",
 		DeleteType:               deleteType,
 		SendMeetingCancellations: sendMeetingCancellations,
-		ItemIds: DeleteItemIds{
+		ItemIds: ItemIds{
 			ItemId: []ItemId{{Id: itemId, ChangeKey: changeKey}},
 		},
 	}
@@ -0,0 +1,78 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// CalendarItemType is shared with the ews package
+type CalendarItemType = ews.CalendarItemType
+
+// CalendarItemType constants
+const (
+	CalendarItemTypeSingle          = ews.CalendarItemTypeSingle
+	CalendarItemTypeOccurrence      = ews.CalendarItemTypeOccurrence
+	CalendarItemTypeException       = ews.CalendarItemTypeException
+	CalendarItemTypeRecurringMaster = ews.CalendarItemTypeRecurringMaster
+)
+
+// Event is a calendar item with its times parsed into the client's time zone, shared with the ews package
+type Event = ews.Event
+
+// ToEvent converts a calendar item returned by EWS into an Event
+func (c *ImpersonationClient) ToEvent(item CalendarItem) (Event, error) {
+	start, err := c.ParseDateTime(item.Start)
+	if err != nil {
+		return Event{}, fmt.Errorf("error parsing start of %q: %w", item.Subject, err)
+	}
+	end, err := c.ParseDateTime(item.End)
+	if err != nil {
+		return Event{}, fmt.Errorf("error parsing end of %q: %w", item.Subject, err)
+	}
+	return ews.NewEvent(item, start, end, c.timeZone), nil
+}
+
+// ToEvents converts calendar items returned by EWS into Events
+func (c *ImpersonationClient) ToEvents(items []CalendarItem) ([]Event, error) {
+	events := make([]Event, 0, len(items))
+	for _, item := range items {
+		event, err := c.ToEvent(item)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// GetEvents retrieves the calendar items of the target user between the specified dates as Events
+func (c *ImpersonationClient) GetEvents(ctx context.Context, startDate, endDate time.Time, targetUserEmail string) ([]Event, error) {
+	items, err := c.GetCalendarItems(ctx, startDate, endDate, targetUserEmail)
+	if err != nil {
+		return nil, err
+	}
+	return c.ToEvents(items)
+}
+
+// NewCalendarEvent returns a CalendarEvent that creates a copy of e, e.g. in another user's calendar.
+// Event.ToCalendarEvent returns the ews.CalendarEvent used by the ews client instead.
+func NewCalendarEvent(e Event) CalendarEvent {
+	return CalendarEvent{
+		Subject:         e.Subject,
+		Body:            e.Body,
+		BodyType:        e.BodyType,
+		Start:           e.Start,
+		End:             e.End,
+		Location:        e.Location,
+		IsAllDay:        e.IsAllDay,
+		ReminderIsSet:   &e.ReminderIsSet,
+		ReminderMinutes: &e.ReminderMinutes,
+		LegacyFreeBusy:  e.FreeBusy,
+		Sensitivity:     e.Sensitivity,
+		Importance:      e.Importance,
+		Categories:      e.Categories,
+	}
+}
@@ -0,0 +1,137 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// defaultFindItemsPageSize is the number of items FindItems returns when no PageSize is given
+const defaultFindItemsPageSize = 100
+
+// FindItemsOptions selects, sorts and pages the items returned by FindItems
+type FindItemsOptions struct {
+	// Folder to search. Defaults to the calendar; other folders are selected with
+	// DistinguishedId, e.g. "inbox", or FolderId.
+	Folder      CalendarFolder
+	Restriction SearchExpression // Optional filter, e.g. ews.Contains(ews.FieldSubject, "review")
+	SortOrder   SortOrder        // Optional sort order, e.g. SortOrder{ews.Descending(ews.FieldStart)}
+	PageSize    int              // Maximum number of items returned; defaults to 100
+	Offset      int              // Number of items to skip, e.g. NextOffset of the previous page
+	Shape       Shape            // Properties returned for each item; defaults to all properties
+}
+
+// FindItemsResult holds a page of items returned by FindItems
+type FindItemsResult struct {
+	CalendarItems []CalendarItem
+	Items         []Item // Messages and other non-calendar items
+	TotalItems    int    // Number of items matching the restriction
+	NextOffset    int    // Offset of the next page
+	Complete      bool   // Whether this page includes the last matching item
+}
+
+// FindItems searches a folder of the target user for items matching a restriction. Unlike
+// GetCalendarItems it does not expand recurring events: a recurring series is returned once,
+// as its master item.
+func (c *ImpersonationClient) FindItems(ctx context.Context, opts FindItemsOptions, targetUserEmail string) (*FindItemsResult, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultFindItemsPageSize
+	}
+
+	request := &FindItemRequest{
+		XMLNSm:    "http://schemas.microsoft.com/exchange/services/2006/messages",
+		Traversal: "Shallow",
+		ItemShape: itemShape(opts.Shape),
+		IndexedPageItemView: &IndexedPageItemView{
+			MaxEntriesReturned: pageSize,
+			Offset:             opts.Offset,
+			BasePoint:          "Beginning",
+		},
+		SortOrder:       opts.SortOrder,
+		ParentFolderIds: opts.Folder.parentFolderIds(),
+	}
+	if opts.Restriction != nil {
+		request.Restriction = &Restriction{Expression: opts.Restriction}
+	}
+
+	var responseEnvelope ResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/FindItem"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	respMsg := responseEnvelope.Body.FindItemResponse.ResponseMessages.FindItemResponseMessage
+	if respMsg.ResponseCode != "NoError" {
+		return nil, fmt.Errorf("EWS error in FindItems: %s", respMsg.ResponseCode)
+	}
+
+	root := respMsg.RootFolder
+	return &FindItemsResult{
+		CalendarItems: root.Items.CalendarItem,
+		Items:         append(root.Items.Message, root.Items.Item...),
+		TotalItems:    root.TotalItems,
+		NextOffset:    root.IndexedPagingOffset,
+		Complete:      root.IncludesLastItemInRange == nil || *root.IncludesLastItemInRange,
+	}, nil
+}
+
+// FindCalendarItemsSeq iterates over the calendar items of the target user matching opts, starting at
+// opts.Offset. Pages of opts.PageSize items are fetched only when the previous one has been consumed.
+func (c *ImpersonationClient) FindCalendarItemsSeq(ctx context.Context, opts FindItemsOptions, targetUserEmail string) iter.Seq2[CalendarItem, error] {
+	return func(yield func(CalendarItem, error) bool) {
+		for page, err := range c.findItemsPages(ctx, opts, targetUserEmail) {
+			if err != nil {
+				yield(CalendarItem{}, err)
+				return
+			}
+			for _, item := range page.CalendarItems {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FindItemsSeq iterates over the messages and other non-calendar items of the target user matching opts,
+// e.g. in the inbox, starting at opts.Offset. Pages are fetched only when the previous one has been consumed.
+func (c *ImpersonationClient) FindItemsSeq(ctx context.Context, opts FindItemsOptions, targetUserEmail string) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		for page, err := range c.findItemsPages(ctx, opts, targetUserEmail) {
+			if err != nil {
+				yield(Item{}, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// findItemsPages iterates over the pages of items matching opts until the last matching item
+func (c *ImpersonationClient) findItemsPages(ctx context.Context, opts FindItemsOptions, targetUserEmail string) iter.Seq2[*FindItemsResult, error] {
+	return func(yield func(*FindItemsResult, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			page, err := c.FindItems(ctx, opts, targetUserEmail)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) || page.Complete || page.NextOffset <= opts.Offset {
+				return
+			}
+			opts.Offset = page.NextOffset
+		}
+	}
+}
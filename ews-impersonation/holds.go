@@ -0,0 +1,257 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// Holds are shared with the ews package, so both clients find each other's holds
+type Hold = ews.Hold
+
+// ErrHoldExpired is returned by ConfirmHold when the hold has expired or was deleted
+var ErrHoldExpired = ews.ErrHoldExpired
+
+// defaultReaperInterval is the time between runs of a HoldReaper when none is given
+const defaultReaperInterval = 5 * time.Minute
+
+// PlaceHolds blocks each slot with a tentative copy of the event in the target user's calendar, tagged
+// with the group and expiry. Holds have no attendees and are created without invitations, whatever the
+// attendees of event; add the attendees when confirming a hold with ConfirmHold. Holds are always
+// placed in the mailbox's calendar, so event.Folder is ignored. If a hold cannot be created, those
+// already created are deleted again.
+// Tentative events only block time for availability options including Tentative in BlockingStatuses.
+func (c *ImpersonationClient) PlaceHolds(ctx context.Context, group string, event CalendarEvent, slots []TimeSlot, expires time.Time, targetUserEmail string) ([]Hold, error) {
+	if group == "" {
+		return nil, fmt.Errorf("hold group is required")
+	}
+
+	events := make([]CalendarEvent, 0, len(slots))
+	for _, slot := range slots {
+		hold := event
+		hold.Start, hold.End = slot.Start, slot.End
+		hold.LegacyFreeBusy = Tentative
+		hold.RequiredAttendees, hold.OptionalAttendees = nil, nil
+		hold.SendInvites = false
+		hold.Folder = CalendarFolder{}
+		hold.ExtendedProperties = append(slices.Clip(event.ExtendedProperties), ews.HoldProperties(group, expires)...)
+		events = append(events, hold)
+	}
+
+	results, err := c.CreateCalendarEvents(ctx, events, "SendToNone", 0, targetUserEmail)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	holds := make([]Hold, 0, len(results))
+	for i, result := range results {
+		switch {
+		case result.ItemId != nil:
+			holds = append(holds, Hold{ItemID: result.ItemId.Id, ChangeKey: result.ItemId.ChangeKey, Group: group, TimeSlot: slots[i], Expires: expires})
+		case err == nil:
+			errs = append(errs, fmt.Errorf("error placing hold at %s: %w", slots[i].Start.Format(time.RFC3339), result.Err))
+		}
+	}
+	if len(errs) > 0 {
+		if _, err := c.deleteHolds(ctx, holds, targetUserEmail); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting placed holds: %w", err))
+		}
+		return nil, errors.Join(errs...)
+	}
+	return holds, nil
+}
+
+// GetHolds returns the holds of the group in the target user's calendar, including expired holds not
+// deleted yet
+func (c *ImpersonationClient) GetHolds(ctx context.Context, group, targetUserEmail string) ([]Hold, error) {
+	return c.findHolds(ctx, ews.HoldsRestriction(group), targetUserEmail)
+}
+
+// ConfirmHold turns the hold into a meeting: it applies the updates, e.g. the subject and attendees,
+// marks the time busy, removes the hold tags and deletes the other holds of its group.
+// sendMeetingInvitations controls the invitations sent to the attendees, e.g. "SendToAllAndSaveCopy".
+// It returns ErrHoldExpired if the hold has expired or no longer exists.
+func (c *ImpersonationClient) ConfirmHold(ctx context.Context, hold Hold, updates EventUpdates, sendMeetingInvitations, targetUserEmail string) error {
+	holds, err := c.GetHolds(ctx, hold.Group, targetUserEmail)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(holds, func(h Hold) bool { return h.ItemID == hold.ItemID })
+	if i < 0 || !holds[i].Expires.After(time.Now()) {
+		return ErrHoldExpired
+	}
+
+	if updates.LegacyFreeBusy == nil {
+		busy := Busy
+		updates.LegacyFreeBusy = &busy
+	}
+	updates.DeleteExtendedProperties = append(slices.Clip(updates.DeleteExtendedProperties), ews.HoldGroupProperty, ews.HoldExpiryProperty)
+	err = c.UpdateCalendarEvent(ctx, hold.ItemID, "", updates, "AlwaysOverwrite", sendMeetingInvitations, targetUserEmail)
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) && responseErr.ResponseCode == "ErrorItemNotFound" {
+		// Deleted by a reaper since it was read
+		return ErrHoldExpired
+	}
+	if err != nil {
+		return err
+	}
+
+	others := slices.Delete(holds, i, i+1)
+	if _, err := c.deleteHolds(ctx, others, targetUserEmail); err != nil {
+		return fmt.Errorf("error releasing other holds: %w", err)
+	}
+	return nil
+}
+
+// ReleaseHolds deletes the holds of the group in the target user's calendar, e.g. when none of the
+// offered times suits
+func (c *ImpersonationClient) ReleaseHolds(ctx context.Context, group, targetUserEmail string) error {
+	holds, err := c.GetHolds(ctx, group, targetUserEmail)
+	if err != nil {
+		return err
+	}
+	_, err = c.deleteHolds(ctx, holds, targetUserEmail)
+	return err
+}
+
+// DeleteExpiredHolds deletes the expired holds of the target user's calendar and returns how many
+// were deleted. Use a HoldReaper to delete them from several mailboxes periodically.
+func (c *ImpersonationClient) DeleteExpiredHolds(ctx context.Context, targetUserEmail string) (int, error) {
+	holds, err := c.findHolds(ctx, ews.ExpiredHoldsRestriction(time.Now()), targetUserEmail)
+	if err != nil {
+		return 0, err
+	}
+	return c.deleteHolds(ctx, holds, targetUserEmail)
+}
+
+// findHolds returns every hold matching the restriction. All pages are read before any is changed,
+// as deleting items would shift the offsets of later pages.
+func (c *ImpersonationClient) findHolds(ctx context.Context, restriction SearchExpression, targetUserEmail string) ([]Hold, error) {
+	var holds []Hold
+	opts := FindItemsOptions{Restriction: restriction, Shape: ews.HoldShape}
+	for item, err := range c.FindCalendarItemsSeq(ctx, opts, targetUserEmail) {
+		if err != nil {
+			return nil, err
+		}
+		group, expires, ok := ews.ReadHold(item.ExtendedProperties)
+		if !ok {
+			continue
+		}
+		start, err := c.ParseDateTime(item.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := c.ParseDateTime(item.End)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, Hold{ItemID: item.ItemId.Id, ChangeKey: item.ItemId.ChangeKey, Group: group, TimeSlot: TimeSlot{Start: start, End: end}, Expires: expires})
+	}
+	return holds, nil
+}
+
+// deleteHolds deletes the holds that have not changed since they were read, and returns how many
+// were deleted. The change key keeps a reaper from deleting a hold confirmed after it was read.
+func (c *ImpersonationClient) deleteHolds(ctx context.Context, holds []Hold, targetUserEmail string) (int, error) {
+	if len(holds) == 0 {
+		return 0, nil
+	}
+	itemIds := make([]ItemId, 0, len(holds))
+	for _, hold := range holds {
+		itemIds = append(itemIds, ItemId{Id: hold.ItemID, ChangeKey: hold.ChangeKey})
+	}
+
+	results, err := c.DeleteCalendarEvents(ctx, itemIds, "HardDelete", "SendToNone", 0, targetUserEmail)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	var errs []error
+	for _, result := range results {
+		var responseErr *ResponseError
+		switch {
+		case errors.As(result.Err, &responseErr) && responseErr.ResponseCode == "ErrorItemNotFound":
+			// Already deleted, e.g. by a reaper running at the same time
+		case errors.As(result.Err, &responseErr) && responseErr.ResponseCode == "ErrorIrresolvableConflict":
+			// Changed since it was read, e.g. confirmed by ConfirmHold
+		case result.Err != nil:
+			errs = append(errs, result.Err)
+		default:
+			deleted++
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// ReapResult is the outcome of deleting the expired holds of one mailbox
+type ReapResult struct {
+	Mailbox string
+	Deleted int
+	Err     error
+}
+
+// HoldReaper deletes the expired holds of several mailboxes, e.g. of every interviewer
+type HoldReaper struct {
+	Client    *ImpersonationClient
+	Mailboxes []string
+	// Interval is the time between runs of Run; defaults to 5 minutes
+	Interval time.Duration
+	// Concurrency is the number of mailboxes reaped at a time; defaults to 4
+	Concurrency int
+	// OnResult is called by Run with the results of each run, e.g. to log errors
+	OnResult func([]ReapResult)
+}
+
+// Reap deletes the expired holds of each mailbox once. An error in one mailbox is recorded in its
+// result and does not stop the others.
+func (r *HoldReaper) Reap(ctx context.Context) []ReapResult {
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultCalendarSourceConcurrency
+	}
+
+	results := make([]ReapResult, len(r.Mailboxes))
+	limit := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, mailbox := range r.Mailboxes {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+			deleted, err := r.Client.DeleteExpiredHolds(ctx, mailbox)
+			results[i] = ReapResult{Mailbox: mailbox, Deleted: deleted, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Run reaps the mailboxes immediately and then every Interval until ctx is done, returning its error
+func (r *HoldReaper) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultReaperInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		results := r.Reap(ctx)
+		if r.OnResult != nil {
+			r.OnResult(results)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
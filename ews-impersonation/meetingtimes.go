@@ -0,0 +1,132 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// The meeting time finder is shared with the ews package; ews.FindMeetingTimes accepts the busy sources below
+type (
+	MeetingAttendee = ews.MeetingAttendee
+	MeetingRequest  = ews.MeetingRequest
+	MeetingSlot     = ews.MeetingSlot
+	AttendeeBusy    = ews.AttendeeBusy
+	BusySource      = ews.BusySource
+
+	SuggestionQuality  = ews.SuggestionQuality
+	SuggestionsOptions = ews.SuggestionsOptions
+	SuggestionDay      = ews.SuggestionDay
+	Suggestion         = ews.Suggestion
+	AttendeeConflict   = ews.AttendeeConflict
+)
+
+// SuggestionQuality constants
+const (
+	SuggestionQualityExcellent = ews.SuggestionQualityExcellent
+	SuggestionQualityGood      = ews.SuggestionQualityGood
+	SuggestionQualityFair      = ews.SuggestionQualityFair
+	SuggestionQualityPoor      = ews.SuggestionQualityPoor
+)
+
+// defaultCalendarSourceConcurrency is the number of calendars CalendarSource reads at a time when none is given
+const defaultCalendarSourceConcurrency = 4
+
+// FreeBusySource is a BusySource reading free/busy information with GetUserAvailability as the target
+// user, who only needs free/busy permission on the mailboxes. The server limits the range to 42 days.
+type FreeBusySource struct {
+	Client          *ImpersonationClient
+	Options         FreeBusyOptions
+	TargetUserEmail string
+}
+
+// BusyTimes implements BusySource
+func (s FreeBusySource) BusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]AttendeeBusy, error) {
+	availability, err := s.Client.GetUserAvailability(ctx, emails, start, end, s.Options, s.TargetUserEmail)
+	if err != nil {
+		return nil, err
+	}
+	return ews.AttendeeBusyTimes(availability), nil
+}
+
+// CalendarSource is a BusySource reading each mailbox's calendar by impersonating its owner, for
+// mailboxes without free/busy sharing or ranges longer than GetUserAvailability accepts
+type CalendarSource struct {
+	Client           *ImpersonationClient
+	ReadWorkingHours bool // Also read each mailbox's working hours with GetWorkingHours
+	Concurrency      int  // Number of mailboxes read at a time; defaults to 4
+}
+
+// BusyTimes implements BusySource. An error reading one mailbox is recorded in its result.
+func (s CalendarSource) BusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]AttendeeBusy, error) {
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultCalendarSourceConcurrency
+	}
+
+	results := make([]AttendeeBusy, len(emails))
+	limit := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, email := range emails {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+			results[i] = s.busyTime(ctx, email, start, end)
+		}()
+	}
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+func (s CalendarSource) busyTime(ctx context.Context, email string, start, end time.Time) AttendeeBusy {
+	busy := AttendeeBusy{Email: email}
+
+	events, err := s.Client.GetEvents(ctx, start, end, email)
+	if err != nil {
+		busy.Err = err
+		return busy
+	}
+	busy.Busy = ews.BusyIntervals(events)
+
+	if s.ReadWorkingHours {
+		busy.WorkingHours, busy.Err = s.Client.GetWorkingHours(ctx, email)
+	}
+	return busy
+}
+
+// FindMeetingTimes finds times at which the attendees can meet, reading their free/busy information
+// with GetUserAvailability as the target user. Use ews.FindMeetingTimes with a CalendarSource to read
+// the attendees' calendars instead.
+func (c *ImpersonationClient) FindMeetingTimes(ctx context.Context, req MeetingRequest, targetUserEmail string) ([]MeetingSlot, error) {
+	return ews.FindMeetingTimes(ctx, FreeBusySource{Client: c, TargetUserEmail: targetUserEmail}, req)
+}
+
+// GetMeetingSuggestions asks the server, as the target user, for meeting times between start and end
+// at which the attendees can meet, rated by how many of them are free and whether the time is within
+// working hours. At most 100 attendees are accepted and the range is limited to 42 days.
+func (c *ImpersonationClient) GetMeetingSuggestions(ctx context.Context, attendees []MeetingAttendee, start, end time.Time, opts SuggestionsOptions, targetUserEmail string) ([]SuggestionDay, error) {
+	if len(attendees) > maxAvailabilityMailboxes {
+		return nil, fmt.Errorf("at most %d attendees can be sent for suggestions, got %d", maxAvailabilityMailboxes, len(attendees))
+	}
+	start = start.In(c.timeZone)
+
+	var responseEnvelope GetUserAvailabilityResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetUserAvailability"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, ews.NewSuggestionsRequest(attendees, start, end, opts), &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseEnvelope.Body.GetUserAvailabilityResponse.SuggestionsResponse
+	if response == nil {
+		return nil, fmt.Errorf("EWS error getting meeting suggestions: no suggestions in response")
+	}
+	return response.Days(attendees, c.timeZone, opts)
+}
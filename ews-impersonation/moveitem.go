@@ -0,0 +1,88 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MoveItem moves an item of any type of the target user, such as an event or a message, to another
+// folder, e.g. CalendarFolder{DistinguishedId: "deleteditems"}. A folder with a Mailbox moves the
+// item to another mailbox, which Exchange only allows between mailboxes in the same organization.
+// Moving an item changes its ID; the new ID is returned, or nil if the server did not report it.
+func (c *ImpersonationClient) MoveItem(ctx context.Context, itemId, changeKey string, to CalendarFolder, targetUserEmail string) (*ItemId, error) {
+	request := &MoveItemRequest{
+		XMLNSm:           "http://schemas.microsoft.com/exchange/services/2006/messages",
+		ToFolderId:       to.targetFolderId(),
+		ItemIds:          ItemIds{ItemId: []ItemId{{Id: itemId, ChangeKey: changeKey}}},
+		ReturnNewItemIds: c.returnNewItemIds(),
+	}
+
+	var responseEnvelope ItemResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/MoveItem"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return moveOrCopyResult(responseEnvelope.Body.Response, "moving")
+}
+
+// CopyItem copies an item of any type of the target user to another folder, possibly in another
+// mailbox, and returns the ID of the copy, or nil if the server did not report it
+func (c *ImpersonationClient) CopyItem(ctx context.Context, itemId, changeKey string, to CalendarFolder, targetUserEmail string) (*ItemId, error) {
+	request := &CopyItemRequest{
+		XMLNSm:           "http://schemas.microsoft.com/exchange/services/2006/messages",
+		ToFolderId:       to.targetFolderId(),
+		ItemIds:          ItemIds{ItemId: []ItemId{{Id: itemId, ChangeKey: changeKey}}},
+		ReturnNewItemIds: c.returnNewItemIds(),
+	}
+
+	var responseEnvelope ItemResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/CopyItem"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return moveOrCopyResult(responseEnvelope.Body.Response, "copying")
+}
+
+func moveOrCopyResult(response ItemResponse, verb string) (*ItemId, error) {
+	messages := response.ResponseMessages.Messages
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("EWS error %s item: no response message returned", verb)
+	}
+
+	respMsg := messages[0]
+	if respMsg.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error %s item: %s. Code: %s", verb, respMsg.ResponseClass, respMsg.ResponseCode)
+	}
+
+	return respMsg.Items.firstItemId(), nil
+}
+
+// returnNewItemIds asks the server to return the IDs of moved and copied items. The element was
+// added in Exchange 2010 SP1; earlier versions always return them and reject the element.
+func (c *ImpersonationClient) returnNewItemIds() *bool {
+	if strings.HasPrefix(c.serverVersion, "Exchange2007") || c.serverVersion == "Exchange2010" {
+		return nil
+	}
+	returnIds := true
+	return &returnIds
+}
+
+// firstItemId returns the ID of the first item of any type, or nil if there are no items
+func (items Items) firstItemId() *ItemId {
+	switch {
+	case len(items.CalendarItem) > 0:
+		return &items.CalendarItem[0].ItemId
+	case len(items.Message) > 0:
+		return &items.Message[0].ItemId
+	case len(items.Item) > 0:
+		return &items.Item[0].ItemId
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// Room structures are shared with the ews package
+type (
+	RoomType = ews.RoomType
+	RoomList = ews.RoomList
+	Room     = ews.Room
+
+	GetRoomListsRequest          = ews.GetRoomListsRequest
+	GetRoomListsResponseEnvelope = ews.GetRoomListsResponseEnvelope
+	GetRoomsRequest              = ews.GetRoomsRequest
+	GetRoomsResponseEnvelope     = ews.GetRoomsResponseEnvelope
+)
+
+// RoomType constants
+const (
+	RoomTypeRoom      = ews.RoomTypeRoom
+	RoomTypeEquipment = ews.RoomTypeEquipment
+)
+
+// GetRoomLists returns the room lists of the organization, as seen by the target user
+func (c *ImpersonationClient) GetRoomLists(ctx context.Context, targetUserEmail string) ([]RoomList, error) {
+	request := &GetRoomListsRequest{XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages"}
+
+	var responseEnvelope GetRoomListsResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetRoomLists"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, request, &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseEnvelope.Body.GetRoomListsResponse
+	if response.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error getting room lists: %s. Code: %s", response.ResponseClass, response.ResponseCode)
+	}
+
+	return ews.RoomListsOf(response.RoomLists), nil
+}
+
+// GetRooms returns the rooms of the room list with the given address, as seen by the target user.
+// Use DescribeRooms to add their type and capacity from WorkMail.
+func (c *ImpersonationClient) GetRooms(ctx context.Context, roomListEmail, targetUserEmail string) ([]Room, error) {
+	var responseEnvelope GetRoomsResponseEnvelope
+	soapAction := "http://schemas.microsoft.com/exchange/services/2006/messages/GetRooms"
+
+	err := c.doRequest(ctx, soapAction, targetUserEmail, ews.NewGetRoomsRequest(roomListEmail), &responseEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseEnvelope.Body.GetRoomsResponse
+	if response.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error getting rooms: %s. Code: %s", response.ResponseClass, response.ResponseCode)
+	}
+
+	return ews.RoomsOf(response.Rooms), nil
+}
+
+// GetWorkMailRooms returns the enabled resources of the WorkMail organization, rooms and equipment,
+// with their type, description and booking options. WorkMail has no capacity attribute, so the
+// capacity is read from the description, e.g. "Capacity: 8" or "12 seats".
+func (c *ImpersonationClient) GetWorkMailRooms(ctx context.Context) ([]Room, error) {
+	var rooms []Room
+	paginator := workmail.NewListResourcesPaginator(c.awsWorkMailClient, &workmail.ListResourcesInput{
+		OrganizationId: &c.workmailOrgID,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list WorkMail resources: %w", err)
+		}
+
+		for _, resource := range page.Resources {
+			if resource.State != types.EntityStateEnabled {
+				continue
+			}
+
+			described, err := c.awsWorkMailClient.DescribeResource(ctx, &workmail.DescribeResourceInput{
+				OrganizationId: &c.workmailOrgID,
+				ResourceId:     resource.Id,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe WorkMail resource %s: %w", aws.ToString(resource.Email), err)
+			}
+
+			room := Room{
+				Name:        aws.ToString(described.Name),
+				Email:       aws.ToString(described.Email),
+				Type:        RoomTypeRoom,
+				Description: aws.ToString(described.Description),
+			}
+			if described.Type == types.ResourceTypeEquipment {
+				room.Type = RoomTypeEquipment
+			}
+			room.Capacity = ews.ParseRoomCapacity(room.Description)
+			if described.BookingOptions != nil {
+				room.AutoAccept = described.BookingOptions.AutoAcceptRequests
+			}
+			rooms = append(rooms, room)
+		}
+	}
+
+	return rooms, nil
+}
+
+// DescribeRooms fills in the type, capacity, description and booking options of the rooms from their
+// WorkMail resources. Rooms without an enabled WorkMail resource are returned unchanged.
+func (c *ImpersonationClient) DescribeRooms(ctx context.Context, rooms []Room) ([]Room, error) {
+	resources, err := c.GetWorkMailRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmail := make(map[string]Room, len(resources))
+	for _, resource := range resources {
+		byEmail[strings.ToLower(resource.Email)] = resource
+	}
+
+	described := make([]Room, 0, len(rooms))
+	for _, room := range rooms {
+		if resource, ok := byEmail[strings.ToLower(room.Email)]; ok {
+			room.Type = resource.Type
+			room.Capacity = resource.Capacity
+			room.Description = resource.Description
+			room.AutoAccept = resource.AutoAccept
+		}
+		described = append(described, room)
+	}
+	return described, nil
+}
+
+// FindFreeRooms returns the rooms with at least minCapacity seats that are free during the slot,
+// smallest first, reading their free/busy information as the target user. The rooms are those of the
+// room list with the given address, or every WorkMail room when roomListEmail is empty, described
+// from WorkMail.
+func (c *ImpersonationClient) FindFreeRooms(ctx context.Context, roomListEmail string, slot TimeSlot, minCapacity int, opts AvailabilityOptions, targetUserEmail string) ([]Room, error) {
+	var rooms []Room
+	var err error
+	if roomListEmail == "" {
+		rooms, err = c.GetWorkMailRooms(ctx)
+	} else {
+		rooms, err = c.GetRooms(ctx, roomListEmail, targetUserEmail)
+		if err == nil {
+			rooms, err = c.DescribeRooms(ctx, rooms)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ews.FindFreeRooms(ctx, FreeBusySource{Client: c, TargetUserEmail: targetUserEmail}, rooms, slot, minCapacity, opts)
+}
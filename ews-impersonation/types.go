@@ -3,20 +3,153 @@ package ewsimpersonation
 import (
 	"encoding/xml"
 	"time"
+
+	"github.com/slav123/ews-workmail/ews"
 )
 
-// LegacyFreeBusyStatus represents the free/busy status of a calendar item
-type LegacyFreeBusyStatus string
+// Item properties and their values are shared with the ews package
+type (
+	LegacyFreeBusyStatus = ews.LegacyFreeBusyStatus
+	Sensitivity          = ews.Sensitivity
+	Importance           = ews.Importance
+	BodyType             = ews.BodyType
+)
 
 // LegacyFreeBusyStatus constants
 const (
-	Free      LegacyFreeBusyStatus = "Free"      // Time slot is available
-	Tentative LegacyFreeBusyStatus = "Tentative" // Time slot is tentatively booked
-	Busy      LegacyFreeBusyStatus = "Busy"      // Time slot is busy
-	OOF       LegacyFreeBusyStatus = "OOF"       // User is Out of Office
-	NoData    LegacyFreeBusyStatus = "NoData"    // Status is unknown
+	Free      = ews.Free
+	Tentative = ews.Tentative
+	Busy      = ews.Busy
+	OOF       = ews.OOF
+	NoData    = ews.NoData
+)
+
+// Sensitivity constants
+const (
+	SensitivityNormal       = ews.SensitivityNormal
+	SensitivityPersonal     = ews.SensitivityPersonal
+	SensitivityPrivate      = ews.SensitivityPrivate
+	SensitivityConfidential = ews.SensitivityConfidential
+)
+
+// Importance constants
+const (
+	ImportanceLow    = ews.ImportanceLow
+	ImportanceNormal = ews.ImportanceNormal
+	ImportanceHigh   = ews.ImportanceHigh
+)
+
+// BodyType constants
+const (
+	BodyTypeText = ews.BodyTypeText
+	BodyTypeHTML = ews.BodyTypeHTML
+	BodyTypeBest = ews.BodyTypeBest
+)
+
+// Time zone structures are shared with the ews package
+type (
+	TimeZoneDefinition = ews.TimeZoneDefinition
+	MeetingTimeZone    = ews.MeetingTimeZone
+	TimeZoneInfo       = ews.TimeZoneInfo
+	ServerTimeZone     = ews.ServerTimeZone
+	TimeZoneIds        = ews.TimeZoneIds
+
+	GetServerTimeZonesRequest          = ews.GetServerTimeZonesRequest
+	GetServerTimeZonesResponseEnvelope = ews.GetServerTimeZonesResponseEnvelope
+)
+
+// Free/busy structures of GetUserAvailability are shared with the ews package
+type (
+	GetUserAvailabilityRequest          = ews.GetUserAvailabilityRequest
+	GetUserAvailabilityResponseEnvelope = ews.GetUserAvailabilityResponseEnvelope
+	FreeBusyViewType                    = ews.FreeBusyViewType
+	FreeBusyOptions                     = ews.FreeBusyOptions
+	AttendeeAvailability                = ews.AttendeeAvailability
+	FreeBusyEvent                       = ews.FreeBusyEvent
+	CalendarEventDetails                = ews.CalendarEventDetails
+)
+
+// FreeBusyViewType constants
+const (
+	FreeBusyViewMergedOnly     = ews.FreeBusyViewMergedOnly
+	FreeBusyViewFreeBusy       = ews.FreeBusyViewFreeBusy
+	FreeBusyViewFreeBusyMerged = ews.FreeBusyViewFreeBusyMerged
+	FreeBusyViewDetailed       = ews.FreeBusyViewDetailed
+	FreeBusyViewDetailedMerged = ews.FreeBusyViewDetailedMerged
+)
+
+// User configuration structures, used to read the working hours, are shared with the ews package
+type (
+	GetUserConfigurationRequest          = ews.GetUserConfigurationRequest
+	GetUserConfigurationResponseEnvelope = ews.GetUserConfigurationResponseEnvelope
+)
+
+// Search restrictions and sort orders are built with the ews package, e.g. ews.Contains(ews.FieldSubject, "review")
+type (
+	SearchExpression = ews.SearchExpression
+	Restriction      = ews.Restriction
+	SortOrder        = ews.SortOrder
+	FieldOrder       = ews.FieldOrder
+)
+
+// Item shapes are shared with the ews package
+type (
+	BaseShape            = ews.BaseShape
+	Shape                = ews.Shape
+	AdditionalProperties = ews.AdditionalProperties
+	PropertyPath         = ews.PropertyPath
+	ExtendedFieldURI     = ews.ExtendedFieldURI
+)
+
+// Extended properties are shared with the ews package
+type (
+	ExtendedProperty     = ews.ExtendedProperty
+	ItemExtendedProperty = ews.ItemExtendedProperty
+)
+
+// Calendar items, as returned by the server, are shared with the ews package
+type (
+	CalendarItem   = ews.CalendarItem
+	ItemId         = ews.ItemId
+	ItemBody       = ews.ItemBody
+	FileAttachment = ews.FileAttachment
+	AttachmentId   = ews.AttachmentId
+	Attendee       = ews.Attendee
+)
+
+// ExtendedProperty returns the extended property of the item identified by path
+func (item Item) ExtendedProperty(path ExtendedFieldURI) (ItemExtendedProperty, bool) {
+	return findExtendedProperty(item.ExtendedProperties, path)
+}
+
+func findExtendedProperty(properties []ItemExtendedProperty, path ExtendedFieldURI) (ItemExtendedProperty, bool) {
+	for _, property := range properties {
+		if property.ExtendedFieldURI.Matches(path) {
+			return property, true
+		}
+	}
+	return ItemExtendedProperty{}, false
+}
+
+// BaseShape constants
+const (
+	BaseShapeIdOnly        = ews.BaseShapeIdOnly
+	BaseShapeDefault       = ews.BaseShapeDefault
+	BaseShapeAllProperties = ews.BaseShapeAllProperties
 )
 
+// itemShape builds the ItemShape of a request, defaulting to all properties
+func itemShape(s Shape) ItemShape {
+	shape := ItemShape{
+		BaseShape:            s.BaseShape,
+		AdditionalProperties: s.AdditionalProperties,
+	}
+	if shape.BaseShape == "" {
+		shape.BaseShape = BaseShapeAllProperties
+	}
+	return shape
+}
+
 // ExchangeImpersonationType defines the structure for the EWS impersonation header
 type ExchangeImpersonationType struct {
 	XMLName       xml.Name          `xml:"t:ExchangeImpersonation"`
@@ -62,34 +195,32 @@ type FindItemResponseMessage struct {
 }
 
 type RootFolder struct {
-	Items      Items `xml:"Items"`
-	TotalItems int   `xml:"TotalItemsInView,attr"`
+	Items                   Items `xml:"Items"`
+	TotalItems              int   `xml:"TotalItemsInView,attr"`
+	IncludesLastItemInRange *bool `xml:"IncludesLastItemInRange,attr"`
+	IndexedPagingOffset     int   `xml:"IndexedPagingOffset,attr"`
 }
 
 type Items struct {
 	CalendarItem []CalendarItem `xml:"CalendarItem"`
+	Message      []Item         `xml:"Message"`
+	Item         []Item         `xml:"Item"`
 }
 
-type CalendarItem struct {
-	ItemId         ItemId `xml:"ItemId"`
-	Subject        string `xml:"Subject"`
-	Start          string `xml:"Start"`
-	End            string `xml:"End"`
-	Location       string `xml:"Location"`
-	IsAllDayEvent  bool   `xml:"IsAllDayEvent,omitempty"`
-	LegacyFreeBusy LegacyFreeBusyStatus `xml:"LegacyFreeBusyStatus,omitempty"`
-	Organizer      struct {
-		Mailbox struct {
-			Name         string `xml:"Name"`
-			EmailAddress string `xml:"EmailAddress"`
-			RoutingType  string `xml:"RoutingType"`
-		} `xml:"Mailbox"`
-	} `xml:"Organizer"`
-}
-
-type ItemId struct {
-	Id        string `xml:"Id,attr"`
-	ChangeKey string `xml:"ChangeKey,attr,omitempty"`
+// Item holds the common properties of a non-calendar item, such as a message
+type Item struct {
+	ItemId           ItemId      `xml:"ItemId"`
+	ItemClass        string      `xml:"ItemClass"`
+	Subject          string      `xml:"Subject"`
+	Sensitivity      Sensitivity `xml:"Sensitivity,omitempty"`
+	Importance       Importance  `xml:"Importance,omitempty"`
+	Categories       []string    `xml:"Categories>String"`
+	HasAttachments   bool        `xml:"HasAttachments"`
+	Size             int         `xml:"Size"`
+	DateTimeCreated  string      `xml:"DateTimeCreated"`
+	DateTimeReceived string      `xml:"DateTimeReceived"`
+
+	ExtendedProperties []ItemExtendedProperty `xml:"ExtendedProperty"`
 }
 
 // Header now includes ExchangeImpersonation
@@ -107,32 +238,77 @@ type Body struct {
 	CreateItem *CreateEventRequest `xml:"m:CreateItem,omitempty"`
 	DeleteItem *DeleteItemRequest  `xml:"m:DeleteItem,omitempty"`
 	UpdateItem *UpdateItemRequest  `xml:"m:UpdateItem,omitempty"`
+	GetItem    *GetItemRequest     `xml:"m:GetItem,omitempty"`
+
+	CreateAttachment *CreateAttachmentRequest `xml:"m:CreateAttachment,omitempty"`
+	GetAttachment    *GetAttachmentRequest    `xml:"m:GetAttachment,omitempty"`
+	DeleteAttachment *DeleteAttachmentRequest `xml:"m:DeleteAttachment,omitempty"`
+
+	GetServerTimeZones *GetServerTimeZonesRequest `xml:"m:GetServerTimeZones,omitempty"`
+
+	FindFolder *FindFolderRequest `xml:"m:FindFolder,omitempty"`
+
+	MoveItem *MoveItemRequest `xml:"m:MoveItem,omitempty"`
+	CopyItem *CopyItemRequest `xml:"m:CopyItem,omitempty"`
+
+	GetUserConfiguration *GetUserConfigurationRequest `xml:"m:GetUserConfiguration,omitempty"`
+	GetUserAvailability  *GetUserAvailabilityRequest  `xml:"m:GetUserAvailabilityRequest,omitempty"`
+
+	GetRoomLists *GetRoomListsRequest `xml:"m:GetRoomLists,omitempty"`
+	GetRooms     *GetRoomsRequest     `xml:"m:GetRooms,omitempty"`
 }
 
 type FindItemRequest struct {
-	XMLName         xml.Name        `xml:"m:FindItem"`
-	XMLNSm          string          `xml:"xmlns:m,attr"`
-	Traversal       string          `xml:"Traversal,attr"`
-	ItemShape       ItemShape       `xml:"m:ItemShape"`
-	CalendarView    CalendarView    `xml:"m:CalendarView"`
-	ParentFolderIds ParentFolderIds `xml:"m:ParentFolderIds"`
+	XMLName             xml.Name             `xml:"m:FindItem"`
+	XMLNSm              string               `xml:"xmlns:m,attr"`
+	Traversal           string               `xml:"Traversal,attr"`
+	ItemShape           ItemShape            `xml:"m:ItemShape"`
+	IndexedPageItemView *IndexedPageItemView `xml:"m:IndexedPageItemView,omitempty"`
+	CalendarView        *CalendarView        `xml:"m:CalendarView,omitempty"`
+	Restriction         *Restriction         `xml:"m:Restriction,omitempty"`
+	SortOrder           SortOrder            `xml:"m:SortOrder,omitempty"`
+	ParentFolderIds     ParentFolderIds      `xml:"m:ParentFolderIds"`
+}
+
+// IndexedPageItemView pages through the items of a folder by offset
+type IndexedPageItemView struct {
+	MaxEntriesReturned int    `xml:"MaxEntriesReturned,attr,omitempty"`
+	Offset             int    `xml:"Offset,attr"`
+	BasePoint          string `xml:"BasePoint,attr"`
 }
 
 type ItemShape struct {
-	BaseShape string `xml:"t:BaseShape"`
+	BaseShape            BaseShape            `xml:"t:BaseShape"`
+	BodyType             BodyType             `xml:"t:BodyType,omitempty"`
+	AdditionalProperties AdditionalProperties `xml:"t:AdditionalProperties,omitempty"`
 }
 
 type CalendarView struct {
-	StartDate string `xml:"StartDate,attr"`
-	EndDate   string `xml:"EndDate,attr"`
+	MaxEntriesReturned int    `xml:"MaxEntriesReturned,attr,omitempty"`
+	StartDate          string `xml:"StartDate,attr"`
+	EndDate            string `xml:"EndDate,attr"`
 }
 
+// ParentFolderIds holds either a FolderId or a DistinguishedFolderId
 type ParentFolderIds struct {
-	DistinguishedFolderId DistinguishedFolderId `xml:"t:DistinguishedFolderId"`
+	FolderId              *FolderId              `xml:"t:FolderId,omitempty"`
+	DistinguishedFolderId *DistinguishedFolderId `xml:"t:DistinguishedFolderId,omitempty"`
+}
+
+type FolderId struct {
+	Id        string `xml:"Id,attr"`
+	ChangeKey string `xml:"ChangeKey,attr,omitempty"`
 }
 
+// DistinguishedFolderId names a well-known folder, such as "calendar", of the caller's
+// mailbox or of the mailbox given in Mailbox
 type DistinguishedFolderId struct {
-	Id string `xml:"Id,attr"`
+	Id      string         `xml:"Id,attr"`
+	Mailbox *FolderMailbox `xml:"t:Mailbox,omitempty"`
+}
+
+type FolderMailbox struct {
+	EmailAddress string `xml:"t:EmailAddress"`
 }
 
 type CreateEventRequest struct {
@@ -143,45 +319,68 @@ type CreateEventRequest struct {
 }
 
 type CreateEventItems struct {
-	CalendarItem CreateEventCalendarItem `xml:"t:CalendarItem"`
+	CalendarItem []CreateEventCalendarItem `xml:"t:CalendarItem"`
 }
 
 type CreateEventCalendarItem struct {
-	XMLNSt            string             `xml:"xmlns,attr"`
-	Subject           string             `xml:"t:Subject"`
-	Body              ItemBody           `xml:"t:Body"`
-	ReminderIsSet     bool               `xml:"t:ReminderIsSet"`
-	ReminderMinutes   int                `xml:"t:ReminderMinutesBeforeStart"`
-	Start             string             `xml:"t:Start"`
-	End               string             `xml:"t:End"`
-	IsAllDayEvent     bool               `xml:"t:IsAllDayEvent"`
+	XMLNSt            string               `xml:"xmlns,attr"`
+	Subject           string               `xml:"t:Subject"`
+	Sensitivity       Sensitivity          `xml:"t:Sensitivity,omitempty"`
+	Body              ItemBody             `xml:"t:Body"`
+	Categories        *ArrayOfStrings      `xml:"t:Categories,omitempty"`
+	Importance        Importance           `xml:"t:Importance,omitempty"`
+	ReminderIsSet     bool                 `xml:"t:ReminderIsSet"`
+	ReminderMinutes   int                  `xml:"t:ReminderMinutesBeforeStart"`
+	ExtendedProperty  []ExtendedProperty   `xml:"t:ExtendedProperty,omitempty"`
+	Start             string               `xml:"t:Start"`
+	End               string               `xml:"t:End"`
+	IsAllDayEvent     bool                 `xml:"t:IsAllDayEvent"`
 	LegacyFreeBusy    LegacyFreeBusyStatus `xml:"t:LegacyFreeBusyStatus"`
-	Location          string             `xml:"t:Location,omitempty"`
-	RequiredAttendees *RequiredAttendees `xml:"t:RequiredAttendees,omitempty"`
-	OptionalAttendees *OptionalAttendees `xml:"t:OptionalAttendees,omitempty"`
+	Location          string               `xml:"t:Location,omitempty"`
+	RequiredAttendees *RequiredAttendees   `xml:"t:RequiredAttendees,omitempty"`
+	OptionalAttendees *OptionalAttendees   `xml:"t:OptionalAttendees,omitempty"`
+	MeetingTimeZone   *MeetingTimeZone     `xml:"t:MeetingTimeZone,omitempty"`
+	StartTimeZone     *TimeZoneDefinition  `xml:"t:StartTimeZone,omitempty"`
+	EndTimeZone       *TimeZoneDefinition  `xml:"t:EndTimeZone,omitempty"`
+}
+
+// ArrayOfStrings is a list of string values, such as item categories
+type ArrayOfStrings struct {
+	String []string `xml:"t:String"`
 }
 
-type ItemBody struct {
-	BodyType string `xml:"BodyType,attr"`
-	Content  string `xml:",chardata"`
+// itemBody builds an ItemBody, defaulting to a plain text body when no type is given
+func itemBody(bodyType BodyType, content string) ItemBody {
+	if bodyType == "" {
+		bodyType = BodyTypeText
+	}
+	return ItemBody{BodyType: string(bodyType), Content: content}
 }
 
+// SavedItemFolderId holds either a FolderId or a DistinguishedFolderId
 type SavedItemFolderId struct {
-	DistinguishedFolderId DistinguishedFolderId `xml:"t:DistinguishedFolderId"`
+	FolderId              *FolderId              `xml:"t:FolderId,omitempty"`
+	DistinguishedFolderId *DistinguishedFolderId `xml:"t:DistinguishedFolderId,omitempty"`
 }
 
 type DeleteItemRequest struct {
-	XMLName                  xml.Name      `xml:"m:DeleteItem"`
-	XMLNSm                   string        `xml:"xmlns:m,attr"`
-	DeleteType               string        `xml:"DeleteType,attr"`
-	SendMeetingCancellations string        `xml:"SendMeetingCancellations,attr"`
-	ItemIds                  DeleteItemIds `xml:"m:ItemIds"`
+	XMLName                  xml.Name `xml:"m:DeleteItem"`
+	XMLNSm                   string   `xml:"xmlns:m,attr"`
+	DeleteType               string   `xml:"DeleteType,attr"`
+	SendMeetingCancellations string   `xml:"SendMeetingCancellations,attr"`
+	ItemIds                  ItemIds  `xml:"m:ItemIds"`
 }
 
-type DeleteItemIds struct {
+// ItemIds lists the items a request operates on
+type ItemIds struct {
 	ItemId []ItemId `xml:"t:ItemId"`
 }
 
+// DeleteItemIds is the former name of ItemIds.
+//
+// Deprecated: use ItemIds.
+type DeleteItemIds = ItemIds
+
 // CreateItem response structures
 type CreateItemResponseEnvelope struct {
 	XMLName xml.Name           `xml:"Envelope"`
@@ -222,18 +421,28 @@ type UpdateItemRequest struct {
 }
 
 type ItemChanges struct {
-	ItemChange ItemChange `xml:"t:ItemChange"`
+	ItemChange []ItemChange `xml:"t:ItemChange"`
 }
 type ItemChange struct {
 	ItemId  ItemId  `xml:"t:ItemId"`
 	Updates Updates `xml:"t:Updates"`
 }
 type Updates struct {
-	SetItemField []SetItemField `xml:"t:SetItemField"`
+	SetItemField    []SetItemField    `xml:"t:SetItemField"`
+	DeleteItemField []DeleteItemField `xml:"t:DeleteItemField,omitempty"`
 }
+
+// SetItemField sets a property identified by either FieldURI or ExtendedFieldURI
 type SetItemField struct {
-	FieldURI     FieldURI           `xml:"t:FieldURI"`
-	CalendarItem UpdateCalendarItem `xml:"t:CalendarItem"`
+	FieldURI         *FieldURI          `xml:"t:FieldURI,omitempty"`
+	ExtendedFieldURI *ExtendedFieldURI  `xml:"t:ExtendedFieldURI,omitempty"`
+	CalendarItem     UpdateCalendarItem `xml:"t:CalendarItem"`
+}
+
+// DeleteItemField removes a property identified by either FieldURI or ExtendedFieldURI
+type DeleteItemField struct {
+	FieldURI         *FieldURI         `xml:"t:FieldURI,omitempty"`
+	ExtendedFieldURI *ExtendedFieldURI `xml:"t:ExtendedFieldURI,omitempty"`
 }
 
 type FieldURI struct {
@@ -241,14 +450,24 @@ type FieldURI struct {
 }
 
 type UpdateCalendarItem struct {
-	Start             *string            `xml:"t:Start,omitempty"`
-	End               *string            `xml:"t:End,omitempty"`
-	Subject           *string            `xml:"t:Subject,omitempty"`
-	Body              *ItemBody          `xml:"t:Body,omitempty"`
+	Start             *string               `xml:"t:Start,omitempty"`
+	End               *string               `xml:"t:End,omitempty"`
+	IsAllDayEvent     *bool                 `xml:"t:IsAllDayEvent,omitempty"`
+	Subject           *string               `xml:"t:Subject,omitempty"`
+	Sensitivity       *Sensitivity          `xml:"t:Sensitivity,omitempty"`
+	Body              *ItemBody             `xml:"t:Body,omitempty"`
+	Categories        *ArrayOfStrings       `xml:"t:Categories,omitempty"`
+	Importance        *Importance           `xml:"t:Importance,omitempty"`
+	ReminderIsSet     *bool                 `xml:"t:ReminderIsSet,omitempty"`
+	ReminderMinutes   *int                  `xml:"t:ReminderMinutesBeforeStart,omitempty"`
+	ExtendedProperty  []ExtendedProperty    `xml:"t:ExtendedProperty,omitempty"`
 	LegacyFreeBusy    *LegacyFreeBusyStatus `xml:"t:LegacyFreeBusyStatus,omitempty"`
-	Location          *string            `xml:"t:Location,omitempty"`
-	RequiredAttendees *RequiredAttendees `xml:"t:RequiredAttendees,omitempty"`
-	OptionalAttendees *OptionalAttendees `xml:"t:OptionalAttendees,omitempty"`
+	Location          *string               `xml:"t:Location,omitempty"`
+	RequiredAttendees *RequiredAttendees    `xml:"t:RequiredAttendees,omitempty"`
+	OptionalAttendees *OptionalAttendees    `xml:"t:OptionalAttendees,omitempty"`
+	MeetingTimeZone   *MeetingTimeZone      `xml:"t:MeetingTimeZone,omitempty"`
+	StartTimeZone     *TimeZoneDefinition   `xml:"t:StartTimeZone,omitempty"`
+	EndTimeZone       *TimeZoneDefinition   `xml:"t:EndTimeZone,omitempty"`
 }
 
 type RequiredAttendees struct {
@@ -269,12 +488,6 @@ type OptionalAttendees struct {
 	Attendees []AttendeeType `xml:"t:Attendee"`
 }
 
-// Attendee represents a meeting attendee for client-side operations
-type Attendee struct {
-	Name  string
-	Email string
-}
-
 // UpdateItem response structures
 type UpdateItemResponseEnvelope struct {
 	XMLName xml.Name               `xml:"Envelope"`
@@ -295,6 +508,7 @@ type UpdateResponseMessages struct {
 
 type UpdateItemResponseMessageType struct {
 	ResponseClass string `xml:"ResponseClass,attr"`
+	MessageText   string `xml:"MessageText"`
 	ResponseCode  string `xml:"ResponseCode"`
 }
 
@@ -302,6 +516,7 @@ type UpdateItemResponseMessageType struct {
 type CalendarEvent struct {
 	Subject           string
 	Body              string
+	BodyType          BodyType // Format of Body; defaults to BodyTypeText when empty
 	Start             time.Time
 	End               time.Time
 	Location          string
@@ -309,6 +524,47 @@ type CalendarEvent struct {
 	RequiredAttendees []Attendee
 	OptionalAttendees []Attendee
 	SendInvites       bool
+	ReminderIsSet     *bool                // Whether a reminder is set; defaults to true when nil
+	ReminderMinutes   *int                 // Minutes before start the reminder fires; defaults to 15 when nil
+	LegacyFreeBusy    LegacyFreeBusyStatus // Free/busy status shown to others; defaults to Busy when empty
+	Sensitivity       Sensitivity          // Optional sensitivity, e.g. SensitivityPrivate
+	Importance        Importance           // Optional importance, e.g. ImportanceHigh
+	Categories        []string             // Optional categories
+	Folder            CalendarFolder       // Calendar the event is created in; defaults to the mailbox's calendar
+
+	ExtendedProperties []ExtendedProperty // Optional extended properties, e.g. IDs of an external system
+}
+
+// defaultReminderMinutes is the reminder lead time used when CalendarEvent.ReminderMinutes is nil
+const defaultReminderMinutes = 15
+
+// reminder returns the reminder settings of the event, applying defaults
+func (e CalendarEvent) reminder() (bool, int) {
+	isSet := true
+	if e.ReminderIsSet != nil {
+		isSet = *e.ReminderIsSet
+	}
+	minutes := defaultReminderMinutes
+	if e.ReminderMinutes != nil {
+		minutes = *e.ReminderMinutes
+	}
+	return isSet, minutes
+}
+
+// freeBusy returns the free/busy status of the event, defaulting to Busy
+func (e CalendarEvent) freeBusy() LegacyFreeBusyStatus {
+	if e.LegacyFreeBusy == "" {
+		return Busy
+	}
+	return e.LegacyFreeBusy
+}
+
+// categoriesOf returns categories in their XML form, or nil if there are none
+func categoriesOf(categories []string) *ArrayOfStrings {
+	if len(categories) == 0 {
+		return nil
+	}
+	return &ArrayOfStrings{String: categories}
 }
 
 // EventUpdates represents updates to an existing calendar event (client-side struct)
@@ -316,11 +572,70 @@ type EventUpdates struct {
 	Start             *time.Time
 	End               *time.Time
 	Subject           *string
-	Body              *string // Assumes ItemBody with BodyType="Text"
+	Body              *string
+	BodyType          BodyType // Format of Body; defaults to BodyTypeText when empty
 	LegacyFreeBusy    *LegacyFreeBusyStatus
 	Location          *string
+	IsAllDay          *bool
 	RequiredAttendees []Attendee
 	OptionalAttendees []Attendee
+	ReminderIsSet     *bool
+	ReminderMinutes   *int
+	Sensitivity       *Sensitivity
+	Importance        *Importance
+	Categories        []string // Replaces the existing categories when non-empty
+
+	ExtendedProperties       []ExtendedProperty // Extended properties to set
+	DeleteExtendedProperties []ExtendedFieldURI // Extended properties to remove
+}
+
+// MoveItem and CopyItem request structures
+type MoveItemRequest struct {
+	XMLName          xml.Name       `xml:"m:MoveItem"`
+	XMLNSm           string         `xml:"xmlns:m,attr"`
+	ToFolderId       TargetFolderId `xml:"m:ToFolderId"`
+	ItemIds          ItemIds        `xml:"m:ItemIds"`
+	ReturnNewItemIds *bool          `xml:"m:ReturnNewItemIds,omitempty"`
+}
+
+type CopyItemRequest struct {
+	XMLName          xml.Name       `xml:"m:CopyItem"`
+	XMLNSm           string         `xml:"xmlns:m,attr"`
+	ToFolderId       TargetFolderId `xml:"m:ToFolderId"`
+	ItemIds          ItemIds        `xml:"m:ItemIds"`
+	ReturnNewItemIds *bool          `xml:"m:ReturnNewItemIds,omitempty"`
+}
+
+type TargetFolderId struct {
+	FolderId              *FolderId              `xml:"t:FolderId,omitempty"`
+	DistinguishedFolderId *DistinguishedFolderId `xml:"t:DistinguishedFolderId,omitempty"`
+}
+
+// Response structures shared by operations on several items, such as MoveItem and batch requests.
+// The names of the response and message elements depend on the operation, so they are matched with any.
+type ItemResponseEnvelope struct {
+	XMLName xml.Name         `xml:"Envelope"`
+	Body    ItemResponseBody `xml:"Body"`
+}
+
+type ItemResponseBody struct {
+	Response ItemResponse `xml:",any"`
+}
+
+type ItemResponse struct {
+	ResponseMessages ItemResponseMessages `xml:"ResponseMessages"`
+}
+
+type ItemResponseMessages struct {
+	// Messages holds one response message per item, in request order
+	Messages []ItemResponseMessage `xml:",any"`
+}
+
+type ItemResponseMessage struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	MessageText   string `xml:"MessageText"`
+	ResponseCode  string `xml:"ResponseCode"`
+	Items         Items  `xml:"Items"`
 }
 
 // DeleteItem response structures
@@ -345,3 +660,151 @@ type DeleteItemResponseMessageType struct {
 	ResponseClass string `xml:"ResponseClass,attr"`
 	ResponseCode  string `xml:"ResponseCode"`
 }
+
+type GetItemRequest struct {
+	XMLName   xml.Name  `xml:"m:GetItem"`
+	XMLNSm    string    `xml:"xmlns:m,attr"`
+	ItemShape ItemShape `xml:"m:ItemShape"`
+	ItemIds   ItemIds   `xml:"m:ItemIds"`
+}
+
+// GetItem response structures
+type GetItemResponseEnvelope struct {
+	XMLName xml.Name            `xml:"Envelope"`
+	Body    GetItemResponseBody `xml:"Body"`
+}
+
+type GetItemResponseBody struct {
+	GetItemResponse GetItemResponseMessage `xml:"GetItemResponse"`
+}
+
+type GetItemResponseMessage struct {
+	ResponseMessages GetItemResponseMessages `xml:"ResponseMessages"`
+}
+
+type GetItemResponseMessages struct {
+	GetItemResponseMessage GetItemResponseMessageType `xml:"GetItemResponseMessage"`
+}
+
+type GetItemResponseMessageType struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	ResponseCode  string `xml:"ResponseCode"`
+	MessageText   string `xml:"MessageText"`
+	Items         Items  `xml:"Items"`
+}
+
+// Attachment request structures
+type CreateAttachmentRequest struct {
+	XMLName      xml.Name          `xml:"m:CreateAttachment"`
+	XMLNSm       string            `xml:"xmlns:m,attr"`
+	ParentItemId ItemId            `xml:"m:ParentItemId"`
+	Attachments  CreateAttachments `xml:"m:Attachments"`
+}
+
+type CreateAttachments struct {
+	FileAttachment []CreateFileAttachment `xml:"t:FileAttachment"`
+}
+
+type CreateFileAttachment struct {
+	Name        string `xml:"t:Name"`
+	ContentType string `xml:"t:ContentType,omitempty"`
+	IsInline    bool   `xml:"t:IsInline"`
+	Content     string `xml:"t:Content"`
+}
+
+type GetAttachmentRequest struct {
+	XMLName       xml.Name      `xml:"m:GetAttachment"`
+	XMLNSm        string        `xml:"xmlns:m,attr"`
+	AttachmentIds AttachmentIds `xml:"m:AttachmentIds"`
+}
+
+type DeleteAttachmentRequest struct {
+	XMLName       xml.Name      `xml:"m:DeleteAttachment"`
+	XMLNSm        string        `xml:"xmlns:m,attr"`
+	AttachmentIds AttachmentIds `xml:"m:AttachmentIds"`
+}
+
+type AttachmentIds struct {
+	AttachmentId []AttachmentId `xml:"t:AttachmentId"`
+}
+
+// Attachment response structures
+type CreateAttachmentResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		CreateAttachmentResponse struct {
+			ResponseMessages struct {
+				CreateAttachmentResponseMessage AttachmentResponseMessageType `xml:"CreateAttachmentResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"CreateAttachmentResponse"`
+	} `xml:"Body"`
+}
+
+type GetAttachmentResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetAttachmentResponse struct {
+			ResponseMessages struct {
+				GetAttachmentResponseMessage AttachmentResponseMessageType `xml:"GetAttachmentResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"GetAttachmentResponse"`
+	} `xml:"Body"`
+}
+
+type DeleteAttachmentResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		DeleteAttachmentResponse struct {
+			ResponseMessages struct {
+				DeleteAttachmentResponseMessage AttachmentResponseMessageType `xml:"DeleteAttachmentResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"DeleteAttachmentResponse"`
+	} `xml:"Body"`
+}
+
+type AttachmentResponseMessageType struct {
+	ResponseClass string           `xml:"ResponseClass,attr"`
+	ResponseCode  string           `xml:"ResponseCode"`
+	MessageText   string           `xml:"MessageText"`
+	Attachments   []FileAttachment `xml:"Attachments>FileAttachment"`
+}
+
+type FindFolderRequest struct {
+	XMLNSm          string          `xml:"xmlns:m,attr"`
+	Traversal       string          `xml:"Traversal,attr"`
+	FolderShape     FolderShape     `xml:"m:FolderShape"`
+	ParentFolderIds ParentFolderIds `xml:"m:ParentFolderIds"`
+}
+
+type FolderShape struct {
+	BaseShape string `xml:"t:BaseShape"`
+}
+
+// FindFolder response structures
+type FindFolderResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		FindFolderResponse struct {
+			ResponseMessages struct {
+				FindFolderResponseMessage FindFolderResponseMessageType `xml:"FindFolderResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"FindFolderResponse"`
+	} `xml:"Body"`
+}
+
+type FindFolderResponseMessageType struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	ResponseCode  string `xml:"ResponseCode"`
+	MessageText   string `xml:"MessageText"`
+	RootFolder    struct {
+		Folders struct {
+			CalendarFolder []FolderInfo `xml:"CalendarFolder"`
+		} `xml:"Folders"`
+	} `xml:"RootFolder"`
+}
+
+// FolderInfo is a folder returned by FindFolder
+type FolderInfo struct {
+	FolderId    FolderId `xml:"FolderId"`
+	DisplayName string   `xml:"DisplayName"`
+}
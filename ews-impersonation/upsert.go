@@ -0,0 +1,84 @@
+package ewsimpersonation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/slav123/ews-workmail/ews"
+)
+
+// ExternalKeyProperty is the extended property UpsertCalendarEvent stores the external key of an event in.
+// It is the same property the ews package uses, so both clients find each other's events.
+var ExternalKeyProperty = ews.ExternalKeyProperty
+
+// UpsertCalendarEvent creates the event for the target user, or updates the event previously created
+// with the same external key with the fields set in event. The key is stored in ExternalKeyProperty, so
+// retrying a create that timed out after the server saved the event updates that event instead of
+// creating a duplicate. An empty Location, Sensitivity, Importance, Categories or attendee list leaves
+// the value of the existing event unchanged, so an upsert cannot clear them or remove every attendee.
+// It returns the ID of the event and whether it was created; the ID of an updated event has no ChangeKey.
+func (c *ImpersonationClient) UpsertCalendarEvent(ctx context.Context, externalKey string, event CalendarEvent, sendMeetingInvitations string, targetUserEmail string) (*ItemId, bool, error) {
+	if externalKey == "" {
+		return nil, false, fmt.Errorf("external key is required")
+	}
+
+	existing, err := c.FindItems(ctx, FindItemsOptions{
+		Folder:      event.Folder,
+		Restriction: ews.IsEqualTo(ExternalKeyProperty, externalKey),
+		Shape:       Shape{BaseShape: BaseShapeIdOnly},
+		PageSize:    1,
+	}, targetUserEmail)
+	if err != nil {
+		return nil, false, fmt.Errorf("error looking up event by external key: %w", err)
+	}
+
+	if len(existing.CalendarItems) > 0 {
+		itemId := existing.CalendarItems[0].ItemId
+		err := c.UpdateCalendarEvent(ctx, itemId.Id, itemId.ChangeKey, event.updates(), "AlwaysOverwrite", sendMeetingInvitations, targetUserEmail)
+		if err != nil {
+			return nil, false, err
+		}
+		return &ItemId{Id: itemId.Id}, false, nil
+	}
+
+	event.ExtendedProperties = append(slices.Clip(event.ExtendedProperties), ews.NewExtendedProperty(ExternalKeyProperty, externalKey))
+	itemId, err := c.CreateCalendarEvent(ctx, event, sendMeetingInvitations, targetUserEmail)
+	if err != nil {
+		return nil, false, err
+	}
+	return itemId, true, nil
+}
+
+// updates returns the changes that set the fields of e on an existing event
+func (e CalendarEvent) updates() EventUpdates {
+	reminderIsSet, reminderMinutes := e.reminder()
+	freeBusy := e.freeBusy()
+
+	updates := EventUpdates{
+		Start:              &e.Start,
+		End:                &e.End,
+		Subject:            &e.Subject,
+		Body:               &e.Body,
+		BodyType:           e.BodyType,
+		LegacyFreeBusy:     &freeBusy,
+		IsAllDay:           &e.IsAllDay,
+		RequiredAttendees:  e.RequiredAttendees,
+		OptionalAttendees:  e.OptionalAttendees,
+		ReminderIsSet:      &reminderIsSet,
+		ReminderMinutes:    &reminderMinutes,
+		Categories:         e.Categories,
+		ExtendedProperties: e.ExtendedProperties,
+	}
+	if e.Location != "" {
+		updates.Location = &e.Location
+	}
+	if e.Sensitivity != "" {
+		updates.Sensitivity = &e.Sensitivity
+	}
+	if e.Importance != "" {
+		updates.Importance = &e.Importance
+	}
+
+	return updates
+}
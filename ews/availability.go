@@ -1,6 +1,8 @@
 package ews
 
 import (
+	"context"
+	"slices"
 	"time"
 )
 
@@ -10,116 +12,323 @@ type TimeSlot struct {
 	End   time.Time
 }
 
-// CheckSlotAvailability checks if a given time slot is available in the calendar
-// It returns true if the slot is available, false if there are conflicts
-func (c *EWSClient) CheckSlotAvailability(slot TimeSlot) (bool, []CalendarItem, error) {
-	// Get all calendar items for the time range
-	// We add a small buffer to make sure we get all relevant events
-	startTime := slot.Start.Add(-1 * time.Minute)
-	endTime := slot.End.Add(1 * time.Minute)
+// BusyInterval is a period of time with the free/busy status of the event occupying it
+type BusyInterval struct {
+	Start  time.Time
+	End    time.Time
+	Status LegacyFreeBusyStatus
+}
 
-	items, err := c.GetCalendarItems(startTime, endTime)
-	if err != nil {
-		return false, nil, err
+// AvailabilityOptions controls which time counts as free and which slots are returned
+type AvailabilityOptions struct {
+	// BlockingStatuses lists the free/busy statuses that make time unavailable; defaults to Busy and OOF.
+	// Add Tentative to also keep clear of tentatively accepted meetings.
+	BlockingStatuses []LegacyFreeBusyStatus
+	// Step is the interval between the start times of returned slots, e.g. 15 minutes; defaults to
+	// the slot duration. Slots start at multiples of Step after midnight.
+	Step time.Duration
+	// WorkingHours limits slots to the working windows of each weekday, e.g. NewWorkingHours(loc,
+	// 9*time.Hour, 17*time.Hour) or the mailbox's own from GetWorkingHours; nil allows any time
+	WorkingHours *WorkingHours
+	// ExcludedDates are days without slots, such as public holidays. Only the date is used, in the
+	// time zone of WorkingHours or of the searched range.
+	ExcludedDates []time.Time
+	// BufferBefore and BufferAfter are the free time kept before and after each blocking event, e.g.
+	// 10 minutes to prepare for a meeting and 15 minutes to get away from the previous one
+	BufferBefore time.Duration
+	BufferAfter  time.Duration
+	// MinimumNotice excludes slots starting sooner than this after Now, e.g. 24 hours
+	MinimumNotice time.Duration
+	// MaxLookahead excludes slots ending later than this after Now, e.g. 30 days; zero allows any time
+	MaxLookahead time.Duration
+	// MaxPerDay closes days that already have this many blocking events, counted on the day they start;
+	// zero allows any number. Days are those of WorkingHours' time zone or of the searched range.
+	MaxPerDay int
+	// Now is the reference time of MinimumNotice and MaxLookahead; defaults to the current time
+	Now time.Time
+}
+
+// defaultBlockingStatuses are the free/busy statuses that block time when none are given
+var defaultBlockingStatuses = []LegacyFreeBusyStatus{Busy, OOF}
+
+// allStatuses are all free/busy statuses, for checks where any event is a conflict
+var allStatuses = []LegacyFreeBusyStatus{Free, Tentative, Busy, OOF, NoData}
+
+// Blocks reports whether time with the given free/busy status is unavailable. Time with an
+// unknown status, e.g. of items retrieved without their free/busy status, is treated as Busy.
+func (o AvailabilityOptions) Blocks(status LegacyFreeBusyStatus) bool {
+	if status == "" {
+		status = Busy
 	}
+	statuses := o.BlockingStatuses
+	if len(statuses) == 0 {
+		statuses = defaultBlockingStatuses
+	}
+	return slices.Contains(statuses, status)
+}
 
-	// If no items found, the slot is available
-	if len(items) == 0 {
-		return true, nil, nil
+// statusRank orders free/busy statuses from least to most restrictive
+var statusRank = map[LegacyFreeBusyStatus]int{
+	NoData:    0,
+	Free:      1,
+	Tentative: 2,
+	Busy:      3,
+	OOF:       4,
+}
+
+// BusyIntervals returns the time occupied by the events, e.g. as returned by GetEvents
+func BusyIntervals(events []Event) []BusyInterval {
+	intervals := make([]BusyInterval, 0, len(events))
+	for _, event := range events {
+		intervals = append(intervals, BusyInterval{Start: event.Start, End: event.End, Status: event.FreeBusy})
 	}
+	return intervals
+}
+
+// MergeBusyIntervals sorts the intervals by start time and merges those that overlap or touch.
+// A merged interval keeps the most restrictive status of its parts, e.g. OOF over Busy.
+func MergeBusyIntervals(intervals []BusyInterval) []BusyInterval {
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b BusyInterval) int {
+		return a.Start.Compare(b.Start)
+	})
 
-	// Check for conflicts - find any events that overlap with our slot
-	var conflicts []CalendarItem
-	for _, item := range items {
-		// Parse start and end times
-		eventStart, err := c.ParseDateTime(item.Start)
-		if err != nil {
-			return false, nil, err
+	var merged []BusyInterval
+	for _, interval := range sorted {
+		if n := len(merged); n > 0 && !interval.Start.After(merged[n-1].End) {
+			last := &merged[n-1]
+			if interval.End.After(last.End) {
+				last.End = interval.End
+			}
+			if statusRank[interval.Status] > statusRank[last.Status] {
+				last.Status = interval.Status
+			}
+			continue
 		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
 
-		eventEnd, err := c.ParseDateTime(item.End)
-		if err != nil {
-			return false, nil, err
+// FreeSlots returns the slots of the given duration between start and end that do not overlap any
+// interval of busy whose status blocks time according to opts, widened by its buffers, nor time
+// outside its working hours, on its excluded dates, within its minimum notice, beyond its maximum
+// lookahead or on days at its daily cap. Slots start every opts.Step, so with a 15 minute step a free
+// hour yields several overlapping 30 minute slots to choose from. The buffers and the daily cap only
+// see the intervals of busy, which should cover opts.SearchRange(start, end).
+func FreeSlots(start, end time.Time, duration time.Duration, busy []BusyInterval, opts AvailabilityOptions) []TimeSlot {
+	return freeSlots(start, end, duration, opts.blocking(start, end, busy), opts)
+}
+
+// blocking returns the intervals of busy whose status blocks time according to opts, widened by its
+// buffers, together with the time between start and end that its other rules close
+func (o AvailabilityOptions) blocking(start, end time.Time, busy []BusyInterval) []BusyInterval {
+	var events, blocking []BusyInterval
+	for _, interval := range busy {
+		if o.Blocks(interval.Status) && interval.End.After(interval.Start) {
+			events = append(events, interval)
+			blocking = append(blocking, BusyInterval{
+				Start:  interval.Start.Add(-o.BufferBefore),
+				End:    interval.End.Add(o.BufferAfter),
+				Status: interval.Status,
+			})
 		}
+	}
+	blocking = append(blocking, o.closedIntervals(start, end)...)
+	blocking = append(blocking, o.noticeIntervals(start, end)...)
+	return append(blocking, o.fullDays(start, end, events)...)
+}
+
+// SearchRange returns the range of events needed to find slots between start and end: widened by the
+// buffers, and to whole days when there is a daily cap
+func (o AvailabilityOptions) SearchRange(start, end time.Time) (time.Time, time.Time) {
+	from := start.Add(-o.BufferAfter)
+	to := end.Add(o.BufferBefore)
+	if o.MaxPerDay > 0 {
+		loc := o.location(start)
+		from = midnight(from.In(loc))
+		to = midnight(to.In(loc).Add(-time.Nanosecond)).AddDate(0, 0, 1)
+	}
+	return from, to
+}
 
-		// Check if this event overlaps with our slot
-		// Overlap occurs when:
-		// 1. Event starts before our slot ends AND
-		// 2. Event ends after our slot starts
-		if eventStart.Before(slot.End) && eventEnd.After(slot.Start) {
-			conflicts = append(conflicts, item)
+// CheckSlot reports whether the slot is available given the busy time around it, which should cover
+// opts.SearchRange(slot.Start, slot.End). It also returns the indexes of the blocking intervals of
+// busy that conflict with the slot, including its buffers. A slot that is unavailable for another
+// reason, such as being outside the working hours or on a day at the cap, has no conflicts.
+func (o AvailabilityOptions) CheckSlot(slot TimeSlot, busy []BusyInterval) (bool, []int) {
+	var conflicts []int
+	for i, interval := range busy {
+		if o.Blocks(interval.Status) && interval.Start.Add(-o.BufferBefore).Before(slot.End) && interval.End.Add(o.BufferAfter).After(slot.Start) {
+			conflicts = append(conflicts, i)
 		}
 	}
+	if len(conflicts) > 0 {
+		return false, conflicts
+	}
 
-	// Slot is available if there are no conflicts
-	return len(conflicts) == 0, conflicts, nil
+	for _, closed := range o.blocking(slot.Start, slot.End, busy) {
+		if closed.Start.Before(slot.End) && closed.End.After(slot.Start) {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
-// GetAvailableSlots finds all available time slots of the specified duration within a time range
-func (c *EWSClient) GetAvailableSlots(startTime, endTime time.Time, slotDuration time.Duration) ([]TimeSlot, error) {
-	// Get all calendar items for the time range
-	items, err := c.GetCalendarItems(startTime, endTime)
-	if err != nil {
-		return nil, err
+// noticeIntervals returns the time between start and end within the minimum notice or beyond the
+// maximum lookahead
+func (o AvailabilityOptions) noticeIntervals(start, end time.Time) []BusyInterval {
+	if o.MinimumNotice <= 0 && o.MaxLookahead <= 0 {
+		return nil
+	}
+	now := o.Now
+	if now.IsZero() {
+		now = time.Now()
 	}
 
-	// If no items, the entire range is available
-	if len(items) == 0 {
-		return []TimeSlot{{Start: startTime, End: endTime}}, nil
+	var closed []BusyInterval
+	if earliest := now.Add(o.MinimumNotice); o.MinimumNotice > 0 && earliest.After(start) {
+		closed = append(closed, BusyInterval{Start: start, End: earliest, Status: OOF})
+	}
+	if latest := now.Add(o.MaxLookahead); o.MaxLookahead > 0 && latest.Before(end) {
+		closed = append(closed, BusyInterval{Start: latest, End: end, Status: OOF})
 	}
+	return closed
+}
 
-	// Parse and sort all events
-	var events []struct {
-		Start time.Time
-		End   time.Time
+// fullDays returns the days between start and end on which at least MaxPerDay of the blocking events start
+func (o AvailabilityOptions) fullDays(start, end time.Time, events []BusyInterval) []BusyInterval {
+	if o.MaxPerDay <= 0 {
+		return nil
 	}
 
-	for _, item := range items {
-		eventStart, err := c.ParseDateTime(item.Start)
-		if err != nil {
-			return nil, err
-		}
+	loc := o.location(start)
+	perDay := make(map[time.Time]int)
+	for _, event := range events {
+		perDay[midnight(event.Start.In(loc))]++
+	}
 
-		eventEnd, err := c.ParseDateTime(item.End)
-		if err != nil {
-			return nil, err
+	var closed []BusyInterval
+	for day := midnight(start.In(loc)); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if perDay[day] >= o.MaxPerDay {
+			closed = append(closed, BusyInterval{Start: day, End: day.AddDate(0, 0, 1), Status: OOF})
 		}
+	}
+	return closed
+}
 
-		events = append(events, struct {
-			Start time.Time
-			End   time.Time
-		}{Start: eventStart, End: eventEnd})
+// location returns the time zone whose days the options apply to
+func (o AvailabilityOptions) location(start time.Time) *time.Location {
+	if o.WorkingHours != nil && o.WorkingHours.Location != nil {
+		return o.WorkingHours.Location
 	}
+	return start.Location()
+}
+
+// midnight returns the start of the day of t in its location
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
 
-	// Sort events by start time
-	// Note: In a real implementation, you would sort the events by start time here
+// freeSlots returns the slots of the given duration between start and end that overlap none of
+// the blocking intervals, starting every opts.Step
+func freeSlots(start, end time.Time, duration time.Duration, blocking []BusyInterval, opts AvailabilityOptions) []TimeSlot {
+	if duration <= 0 {
+		return nil
+	}
+	step := opts.Step
+	if step <= 0 {
+		step = duration
+	}
+	blocking = MergeBusyIntervals(blocking)
 
-	// Find available slots
-	var availableSlots []TimeSlot
-	currentTime := startTime
+	var slots []TimeSlot
+	next := 0 // First blocking interval that does not end before the current slot
+	for slotStart := alignToStep(start, step); !slotStart.Add(duration).After(end); {
+		slotEnd := slotStart.Add(duration)
 
-	for _, event := range events {
-		// If there's time before this event, check if it's enough for a slot
-		if event.Start.Sub(currentTime) >= slotDuration {
-			availableSlots = append(availableSlots, TimeSlot{
-				Start: currentTime,
-				End:   event.Start,
-			})
+		for next < len(blocking) && !blocking[next].End.After(slotStart) {
+			next++
 		}
-
-		// Move current time to the end of this event
-		if event.End.After(currentTime) {
-			currentTime = event.End
+		if next < len(blocking) && blocking[next].Start.Before(slotEnd) {
+			// Continue with the first start after the interval the slot overlaps
+			slotStart = alignToStep(blocking[next].End.In(start.Location()), step)
+			continue
 		}
+
+		slots = append(slots, TimeSlot{Start: slotStart, End: slotEnd})
+		slotStart = alignToStep(slotStart.Add(step), step)
+	}
+
+	return slots
+}
+
+// alignToStep rounds t up to the next multiple of step after midnight of its day on the wall clock,
+// so slots keep starting on the hour and half hour after a daylight saving time change
+func alignToStep(t time.Time, step time.Duration) time.Time {
+	wall := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	remainder := wall % step
+	if remainder == 0 {
+		return t
 	}
+	aligned := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, int(wall+step-remainder), t.Location())
+	if aligned.Before(t) {
+		// The wall clock time repeats when the clocks go back, and time.Date picks its first occurrence
+		return t.Add(step - remainder)
+	}
+	return aligned
+}
 
-	// Check if there's time after the last event
-	if endTime.Sub(currentTime) >= slotDuration {
-		availableSlots = append(availableSlots, TimeSlot{
-			Start: currentTime,
-			End:   endTime,
-		})
+// CheckSlotAvailability checks if a given time slot is available in the calendar
+// It returns true if the slot is available, false if there are conflicts
+// Every overlapping event is a conflict, whatever its free/busy status
+func (c *EWSClient) CheckSlotAvailability(slot TimeSlot) (bool, []CalendarItem, error) {
+	return c.CheckSlotAvailabilityWithOptions(context.Background(), slot, AvailabilityOptions{BlockingStatuses: allStatuses})
+}
+
+// CheckSlotAvailabilityWithOptions checks if a given time slot is available in the calendar, applying
+// the rules of opts: only events whose free/busy status blocks time conflict, including their buffers.
+// A slot that opts rules out otherwise, e.g. outside the working hours, is unavailable without conflicts.
+func (c *EWSClient) CheckSlotAvailabilityWithOptions(ctx context.Context, slot TimeSlot, opts AvailabilityOptions) (bool, []CalendarItem, error) {
+	if !opts.Allows(slot) {
+		return false, nil, nil
+	}
+
+	// Get all calendar items that can conflict with the slot or count towards its day
+	startTime, endTime := opts.SearchRange(slot.Start, slot.End)
+	items, err := c.GetCalendarItemsInFolder(ctx, CalendarFolder{}, startTime, endTime)
+	if err != nil {
+		return false, nil, err
+	}
+	events, err := c.ToEvents(items)
+	if err != nil {
+		return false, nil, err
+	}
+
+	available, indexes := opts.CheckSlot(slot, BusyIntervals(events))
+	conflicts := make([]CalendarItem, 0, len(indexes))
+	for _, i := range indexes {
+		conflicts = append(conflicts, items[i])
+	}
+	return available, conflicts, nil
+}
+
+// GetAvailableSlots finds all available time slots of the specified duration within a time range.
+// Slots start every slotDuration; Free and Tentative events do not block time.
+func (c *EWSClient) GetAvailableSlots(startTime, endTime time.Time, slotDuration time.Duration) ([]TimeSlot, error) {
+	return c.GetAvailableSlotsWithOptions(context.Background(), startTime, endTime, slotDuration, AvailabilityOptions{})
+}
+
+// GetAvailableSlotsWithOptions finds all available time slots of the specified duration within
+// a time range, applying the blocking statuses, step, working hours and booking rules of opts
+func (c *EWSClient) GetAvailableSlotsWithOptions(ctx context.Context, startTime, endTime time.Time, slotDuration time.Duration, opts AvailabilityOptions) ([]TimeSlot, error) {
+	// Get all events that can block slots in the time range
+	from, to := opts.SearchRange(startTime, endTime)
+	events, err := c.GetEvents(ctx, from, to)
+	if err != nil {
+		return nil, err
 	}
 
-	return availableSlots, nil
+	return FreeSlots(startTime, endTime, slotDuration, BusyIntervals(events), opts), nil
 }
@@ -0,0 +1,189 @@
+package ews
+
+import (
+	"context"
+	"fmt"
+)
+
+// defaultBatchSize is the number of items sent per request when no batch size is given
+const defaultBatchSize = 50
+
+// ResponseError is an error the server reported for a single item of a request
+type ResponseError struct {
+	ResponseClass string // "Error", or "Warning" when the request was only partly applied
+	ResponseCode  string // e.g. "ErrorItemNotFound"
+	MessageText   string
+}
+
+func (e *ResponseError) Error() string {
+	if e.MessageText == "" {
+		return fmt.Sprintf("EWS error: %s", e.ResponseCode)
+	}
+	return fmt.Sprintf("EWS error: %s: %s", e.ResponseCode, e.MessageText)
+}
+
+// err returns the error reported for the item, or nil if it succeeded
+func (m ItemResponseMessage) err() error {
+	if m.ResponseClass == "Success" {
+		return nil
+	}
+	return &ResponseError{ResponseClass: m.ResponseClass, ResponseCode: m.ResponseCode, MessageText: m.MessageText}
+}
+
+// BatchResult is the outcome of one item of a batch operation
+type BatchResult struct {
+	ItemId *ItemId       // ID of the item; nil for deleted and failed items
+	Item   *CalendarItem // Item returned by the server; only GetCalendarItemsByID returns more than its ID
+	Err    error         // *ResponseError if the server rejected the item, or the error of the whole request
+}
+
+// EventChange holds the updates applied to one event by UpdateCalendarEvents
+type EventChange struct {
+	ItemId  ItemId
+	Updates EventUpdates
+}
+
+// CreateCalendarEvents creates the events with one request per batch of batchSize events, or 50 when
+// batchSize is zero. Consecutive events are only sent together when they share Folder and SendInvites.
+// The results are in the order of events.
+func (c *EWSClient) CreateCalendarEvents(ctx context.Context, events []CalendarEvent, batchSize int) ([]BatchResult, error) {
+	for _, event := range events {
+		if err := CheckTimeZones(event.Start, event.End); err != nil {
+			return nil, err
+		}
+	}
+	split := func(i int) bool {
+		return events[i].Folder != events[i-1].Folder || events[i].SendInvites != events[i-1].SendInvites
+	}
+
+	return c.runBatches(ctx, len(events), batchSize, split, func(start, end int) Body {
+		request := &CreateEventRequest{
+			SendMeetingInvitations: events[start].sendMeetingInvitations(),
+			SavedItemFolderId:      events[start].Folder.savedItemFolderId(),
+		}
+		for _, event := range events[start:end] {
+			request.Items.CalendarItem = append(request.Items.CalendarItem, c.newCalendarItem(event))
+		}
+		return Body{CreateItem: request}
+	})
+}
+
+// UpdateCalendarEvents applies the changes with one request per batch of batchSize events, or 50 when
+// batchSize is zero. The results are in the order of changes.
+// conflictResolution can be "NeverOverwrite", "AutoResolve", "AlwaysOverwrite";
+// sendMeetingInvitationsOrCancellations can be "SendToNone", "SendOnlyToAll", "SendOnlyToChanged",
+// "SendToAllAndSaveCopy", "SendToChangedAndSaveCopy".
+func (c *EWSClient) UpdateCalendarEvents(ctx context.Context, changes []EventChange, conflictResolution, sendMeetingInvitationsOrCancellations string, batchSize int) ([]BatchResult, error) {
+	for _, change := range changes {
+		if err := change.Updates.checkTimeZones(); err != nil {
+			return nil, err
+		}
+	}
+	return c.runBatches(ctx, len(changes), batchSize, nil, func(start, end int) Body {
+		request := &UpdateItemRequest{
+			XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ConflictResolution:     conflictResolution,
+			SendMeetingInvitations: sendMeetingInvitationsOrCancellations,
+			MessageDisposition:     "SaveOnly",
+		}
+		for _, change := range changes[start:end] {
+			request.ItemChanges.ItemChange = append(request.ItemChanges.ItemChange, c.itemChange(change.ItemId, change.Updates))
+		}
+		return Body{UpdateItem: request}
+	})
+}
+
+// DeleteCalendarEvents deletes the events with one request per batch of batchSize events, or 50 when
+// batchSize is zero. The results are in the order of itemIDs.
+// deleteType can be "HardDelete", "SoftDelete", "MoveToDeletedItems";
+// sendMeetingCancellations can be "SendToNone", "SendOnlyToAll", "SendToAllAndSaveCopy".
+func (c *EWSClient) DeleteCalendarEvents(ctx context.Context, itemIDs []string, deleteType, sendMeetingCancellations string, batchSize int) ([]BatchResult, error) {
+	return c.deleteItems(ctx, itemIdsOf(itemIDs).ItemId, deleteType, sendMeetingCancellations, batchSize)
+}
+
+// deleteItems deletes the items like DeleteCalendarEvents. Items with a ChangeKey are only deleted
+// if they have not changed since, otherwise their result is an ErrorIrresolvableConflict error.
+func (c *EWSClient) deleteItems(ctx context.Context, itemIds []ItemId, deleteType, sendMeetingCancellations string, batchSize int) ([]BatchResult, error) {
+	return c.runBatches(ctx, len(itemIds), batchSize, nil, func(start, end int) Body {
+		return Body{
+			DeleteItem: &DeleteItemRequest{
+				XMLNSm:                   "http://schemas.microsoft.com/exchange/services/2006/messages",
+				DeleteType:               deleteType,
+				SendMeetingCancellations: sendMeetingCancellations,
+				ItemIds:                  ItemIds{ItemId: itemIds[start:end]},
+			},
+		}
+	})
+}
+
+// GetCalendarItemsByID retrieves calendar items by their IDs, including their bodies, with one
+// request per batch of batchSize items, or 50 when batchSize is zero. The results are in the order
+// of itemIDs; bodyType selects the format bodies are returned in.
+func (c *EWSClient) GetCalendarItemsByID(ctx context.Context, itemIDs []string, bodyType BodyType, batchSize int) ([]BatchResult, error) {
+	return c.runBatches(ctx, len(itemIDs), batchSize, nil, func(start, end int) Body {
+		return Body{
+			GetItem: &GetItemRequest{
+				XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
+				ItemShape: ItemShape{
+					BaseShape: "AllProperties",
+					BodyType:  bodyType,
+				},
+				ItemIds: itemIdsOf(itemIDs[start:end]),
+			},
+		}
+	})
+}
+
+func itemIdsOf(itemIDs []string) ItemIds {
+	ids := ItemIds{ItemId: make([]ItemId, 0, len(itemIDs))}
+	for _, id := range itemIDs {
+		ids.ItemId = append(ids.ItemId, ItemId{Id: id})
+	}
+	return ids
+}
+
+// runBatches sends n items in requests of at most batchSize items built by build, which receives the
+// range of items to include. split reports whether item i cannot share a request with item i-1.
+// The server returns one response message per item, in request order. If a request fails, its error
+// is recorded for the items of that and all later batches, which are not sent, and returned.
+func (c *EWSClient) runBatches(ctx context.Context, n, batchSize int, split func(i int) bool, build func(start, end int) Body) ([]BatchResult, error) {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	results := make([]BatchResult, n)
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && end-start < batchSize && (split == nil || !split(end)) {
+			end++
+		}
+
+		var responseEnvelope ItemResponseEnvelope
+		err := c.doRequest(ctx, build(start, end), &responseEnvelope)
+		messages := responseEnvelope.Body.Response.ResponseMessages.Messages
+		if err == nil && len(messages) != end-start {
+			err = fmt.Errorf("expected %d response messages, got %d", end-start, len(messages))
+		}
+		if err != nil {
+			for i := start; i < n; i++ {
+				results[i].Err = err
+			}
+			return results, err
+		}
+
+		for i, message := range messages {
+			result := &results[start+i]
+			if result.Err = message.err(); result.Err != nil {
+				continue
+			}
+			result.ItemId = message.Items.firstItemId()
+			if len(message.Items.CalendarItem) > 0 {
+				result.Item = &message.Items.CalendarItem[0]
+			}
+		}
+
+		start = end
+	}
+
+	return results, nil
+}
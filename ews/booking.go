@@ -0,0 +1,158 @@
+package ews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SlotConflictError is returned by BookSlot when the slot is not available
+type SlotConflictError struct {
+	Slot TimeSlot
+	// Conflicts are the events that took the slot first. It is empty when the slot was ruled out by
+	// the availability options, e.g. outside the working hours or on a full day.
+	Conflicts []CalendarItem
+	// RolledBack is true when the event had been created and was deleted again because of another
+	// booking made at the same time
+	RolledBack bool
+}
+
+func (e *SlotConflictError) Error() string {
+	slot := fmt.Sprintf("%s - %s", e.Slot.Start.Format(time.RFC3339), e.Slot.End.Format(time.RFC3339))
+	if len(e.Conflicts) == 0 {
+		return fmt.Sprintf("slot %s is not available", slot)
+	}
+	subjects := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		subjects = append(subjects, fmt.Sprintf("%q", conflict.Subject))
+	}
+	return fmt.Sprintf("slot %s conflicts with %s", slot, strings.Join(subjects, ", "))
+}
+
+// BookSlot creates the event if its time is available according to opts, and makes sure that of
+// several bookings racing for the same time at most one is kept. After creating the event the calendar
+// is read again and checked with VerifyBooking; if the event lost the slot, it is deleted without
+// sending cancellations and a *SlotConflictError with RolledBack set is returned. Invitations are sent
+// when the event is created, so a rolled back booking with SendInvites leaves them behind.
+// If the calendar cannot be read again, the ID of the unverified event is returned with the error.
+func (c *EWSClient) BookSlot(ctx context.Context, event CalendarEvent, opts AvailabilityOptions) (*string, error) {
+	slot := TimeSlot{Start: event.Start, End: event.End}
+	if opts.Now.IsZero() {
+		// Check and re-check the notice and lookahead against the same time
+		opts.Now = time.Now()
+	}
+	if !opts.Allows(slot) {
+		return nil, &SlotConflictError{Slot: slot}
+	}
+
+	items, busy, err := c.slotBusyTime(ctx, event.Folder, slot, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckBooking(slot, opts, items, busy); err != nil {
+		return nil, err
+	}
+
+	itemID, err := c.CreateCalendarEventContext(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	items, busy, err = c.slotBusyTime(ctx, event.Folder, slot, opts)
+	if err != nil {
+		return itemID, fmt.Errorf("error verifying booking: %w", err)
+	}
+	conflictErr := VerifyBooking(slot, opts, items, busy, *itemID)
+	if conflictErr == nil {
+		return itemID, nil
+	}
+
+	results, err := c.DeleteCalendarEvents(ctx, []string{*itemID}, "HardDelete", "SendToNone", 1)
+	if err == nil {
+		err = results[0].Err
+	}
+	if err != nil {
+		return nil, errors.Join(conflictErr, fmt.Errorf("error rolling back booking: %w", err))
+	}
+	return nil, conflictErr
+}
+
+// CheckBooking checks a slot about to be booked against the items around it and their busy time,
+// read over opts.SearchRange of the slot. It returns a *SlotConflictError if the slot is not available.
+func CheckBooking(slot TimeSlot, opts AvailabilityOptions, items []CalendarItem, busy []BusyInterval) error {
+	if available, indexes := opts.CheckSlot(slot, busy); !available {
+		return &SlotConflictError{Slot: slot, Conflicts: pick(items, indexes)}
+	}
+	return nil
+}
+
+// VerifyBooking checks the booking with the item ID against the items read again after creating it,
+// and returns a *SlotConflictError with RolledBack set if the booking must be deleted. The booking
+// only yields to items created before it, and to items without a known creation time. Creation times
+// have whole seconds only, so of items created in the same second the one with the smaller item ID
+// counts as created first: exactly one of the bookings racing for a slot keeps it.
+func VerifyBooking(slot TimeSlot, opts AvailabilityOptions, items []CalendarItem, busy []BusyInterval, itemID string) error {
+	var own *CalendarItem
+	for i := range items {
+		if items[i].ItemId.Id == itemID {
+			own = &items[i]
+		}
+	}
+
+	var earlierItems []CalendarItem
+	var earlierBusy []BusyInterval
+	for i, item := range items {
+		if item.ItemId.Id == itemID || (own != nil && createdAfter(item, *own)) {
+			continue
+		}
+		earlierItems = append(earlierItems, item)
+		earlierBusy = append(earlierBusy, busy[i])
+	}
+
+	if available, indexes := opts.CheckSlot(slot, earlierBusy); !available {
+		return &SlotConflictError{Slot: slot, Conflicts: pick(earlierItems, indexes), RolledBack: true}
+	}
+	return nil
+}
+
+// slotBusyTime reads the items of the folder that can conflict with the slot and their busy time
+func (c *EWSClient) slotBusyTime(ctx context.Context, folder CalendarFolder, slot TimeSlot, opts AvailabilityOptions) ([]CalendarItem, []BusyInterval, error) {
+	from, to := opts.SearchRange(slot.Start, slot.End)
+	items, err := c.GetCalendarItemsInFolder(ctx, folder, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, err := c.ToEvents(items)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, BusyIntervals(events), nil
+}
+
+// createdAfter reports whether a was created after b. It is false when either creation time is
+// unknown.
+func createdAfter(a, b CalendarItem) bool {
+	createdA, errA := time.Parse(time.RFC3339, a.DateTimeCreated)
+	createdB, errB := time.Parse(time.RFC3339, b.DateTimeCreated)
+	if errA != nil || errB != nil {
+		return false
+	}
+	// Bookings racing for a slot are often created in the same second, so ties are broken by the
+	// item IDs, which every client compares the same way: otherwise each would yield to the other
+	// and all of them would be rolled back
+	if createdA.Equal(createdB) {
+		return a.ItemId.Id > b.ItemId.Id
+	}
+	return createdA.After(createdB)
+}
+
+// pick returns the items at the given indexes
+func pick[T any](items []T, indexes []int) []T {
+	picked := make([]T, 0, len(indexes))
+	for _, i := range indexes {
+		picked = append(picked, items[i])
+	}
+	return picked
+}
@@ -2,6 +2,7 @@ package ews
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -18,6 +19,19 @@ type EWSClient struct {
 	Client   *http.Client
 	// TimeZone location for consistent timezone handling
 	TimeZone *time.Location
+	// ServerVersion is the EWS schema version requested; defaults to Exchange2010 when empty
+	ServerVersion string
+}
+
+// defaultServerVersion is the EWS schema version requested when ServerVersion is not set
+const defaultServerVersion = "Exchange2010"
+
+// serverVersion returns the EWS schema version to request
+func (c *EWSClient) serverVersion() string {
+	if c.ServerVersion == "" {
+		return defaultServerVersion
+	}
+	return c.ServerVersion
 }
 
 // NewClient creates a new EWS client with the provided credentials
@@ -30,7 +44,8 @@ func NewClient(url, username, password string) *EWSClient {
 		Client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		TimeZone: time.Local, // Default to local timezone
+		TimeZone:      time.Local, // Default to local timezone
+		ServerVersion: defaultServerVersion,
 	}
 }
 
@@ -48,7 +63,8 @@ func NewClientWithTimezone(url, username, password, timezone string) (*EWSClient
 		Client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		TimeZone: loc,
+		TimeZone:      loc,
+		ServerVersion: defaultServerVersion,
 	}, nil
 }
 
@@ -68,6 +84,11 @@ func (c *EWSClient) FormatDateWithoutTZ(t time.Time) string {
 	return inTZ.Format("2006-01-02T15:04:05")
 }
 
+// formatDateInLocation formats a time.Time with the offset of its own location
+func formatDateInLocation(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05-07:00")
+}
+
 // ParseDateTime parses a datetime string from EWS response into a time.Time
 // with the client's timezone
 func (c *EWSClient) ParseDateTime(dateStr string) (time.Time, error) {
@@ -113,53 +134,36 @@ func (c *EWSClient) SetTimezone(timezone string) error {
 	return nil
 }
 
-// GetCalendarItems retrieves calendar items between the specified dates
-func (c *EWSClient) GetCalendarItems(startDate, endDate time.Time) ([]CalendarItem, error) {
-	// Format dates for EWS request using timezone-aware formatting
-	startDateStr := c.FormatDateWithTZ(startDate)
-	endDateStr := c.FormatDateWithTZ(endDate)
-
-	// Prepare the SOAP envelope
+// marshalEnvelope wraps the given body in a SOAP envelope and converts it to XML
+func (c *EWSClient) marshalEnvelope(body Body) ([]byte, error) {
 	envelope := Envelope{
 		XMLNS:  "http://schemas.xmlsoap.org/soap/envelope/",
 		XMLNSt: "http://schemas.microsoft.com/exchange/services/2006/types",
 		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
 		Header: Header{
 			ServerVersionInfo: ServerVersionInfo{
-				Version: "Exchange2010",
-			},
-		},
-		Body: Body{
-			FindItem: &FindItemRequest{
-				XMLNSm:    "http://schemas.microsoft.com/exchange/services/2006/messages",
-				Traversal: "Shallow",
-				ItemShape: ItemShape{
-					BaseShape: "AllProperties",
-				},
-				CalendarView: CalendarView{
-					StartDate: startDateStr,
-					EndDate:   endDateStr,
-				},
-				ParentFolderIds: ParentFolderIds{
-					DistinguishedFolderId: DistinguishedFolderId{
-						Id: "calendar",
-					},
-				},
+				Version: c.serverVersion(),
 			},
 		},
+		Body: body,
 	}
 
-	// Convert the envelope to XML
 	xmlData, err := xml.MarshalIndent(envelope, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("error marshalling request: %w", err)
 	}
+	return xmlData, nil
+}
 
+// post sends a SOAP payload to the EWS endpoint and returns the response once
+// its status has been checked. The caller must close the response body.
+func (c *EWSClient) post(ctx context.Context, payload io.Reader, contentLength int64) (*http.Response, error) {
 	// Create the HTTP request
-	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(xmlData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, payload)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
+	req.ContentLength = contentLength
 
 	// Set headers
 	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
@@ -170,99 +174,249 @@ func (c *EWSClient) GetCalendarItems(startDate, endDate time.Time) ([]CalendarIt
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %w", err)
 	}
-	defer resp.Body.Close()
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
+	return resp, nil
+}
+
+// doRequest wraps the given body in a SOAP envelope, sends it to the EWS endpoint
+// and unmarshals the response into responseBody
+func (c *EWSClient) doRequest(ctx context.Context, body Body, responseBody interface{}) error {
+	xmlData, err := c.marshalEnvelope(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.post(ctx, bytes.NewReader(xmlData), int64(len(xmlData)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
 	// Read and parse the response
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if err := xml.Unmarshal(respBody, responseBody); err != nil {
+		return fmt.Errorf("error unmarshalling response: %w", err)
+	}
+
+	return nil
+}
+
+// doItemRequest sends a request on a single item and returns its response message, or the error
+// the server reported for the item
+func (c *EWSClient) doItemRequest(ctx context.Context, body Body) (*ItemResponseMessage, error) {
+	var responseEnvelope ItemResponseEnvelope
+	if err := c.doRequest(ctx, body, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	messages := responseEnvelope.Body.Response.ResponseMessages.Messages
+	if len(messages) != 1 {
+		return nil, fmt.Errorf("expected 1 response message, got %d", len(messages))
+	}
+	if err := messages[0].err(); err != nil {
+		return nil, err
+	}
+	return &messages[0], nil
+}
+
+// GetCalendarItems retrieves calendar items between the specified dates. Long ranges are
+// fetched in several requests; ErrIncompleteCalendarView is returned if the server did not
+// return every item. Use FindCalendarItems to control paging or to accept partial results.
+func (c *EWSClient) GetCalendarItems(startDate, endDate time.Time) ([]CalendarItem, error) {
+	return c.GetCalendarItemsInFolder(context.Background(), CalendarFolder{}, startDate, endDate)
+}
+
+// GetCalendarItemsInFolder retrieves calendar items between the specified dates from the given
+// calendar, such as a project calendar or another mailbox's calendar
+func (c *EWSClient) GetCalendarItemsInFolder(ctx context.Context, folder CalendarFolder, startDate, endDate time.Time) ([]CalendarItem, error) {
+	result, err := c.FindCalendarItems(ctx, startDate, endDate, CalendarViewOptions{Folder: folder})
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		return nil, err
+	}
+	if !result.Complete {
+		return nil, fmt.Errorf("%w: %d items found between %s and %s", ErrIncompleteCalendarView,
+			len(result.Items), c.FormatDateWithTZ(startDate), c.FormatDateWithTZ(endDate))
 	}
 
-	// Parse the response
-	var responseEnvelope ResponseEnvelope
-	if err := xml.Unmarshal(body, &responseEnvelope); err != nil {
-		return nil, fmt.Errorf("error unmarshalling response: %w", err)
+	return result.Items, nil
+}
+
+// GetCalendarItem retrieves a single calendar item by its ID, including its body.
+// bodyType selects the format the body is returned in; an empty value lets the server decide.
+func (c *EWSClient) GetCalendarItem(ctx context.Context, itemID string, bodyType BodyType) (*CalendarItem, error) {
+	body := Body{
+		GetItem: &GetItemRequest{
+			XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ItemShape: ItemShape{
+				BaseShape: "AllProperties",
+				BodyType:  bodyType,
+			},
+			ItemIds: itemIdsOf([]string{itemID}),
+		},
+	}
+
+	var responseEnvelope GetItemResponseEnvelope
+	if err := c.doRequest(ctx, body, &responseEnvelope); err != nil {
+		return nil, err
 	}
 
 	// Check response code
-	responseMessage := responseEnvelope.Body.FindItemResponse.ResponseMessages.FindItemResponseMessage
-	if responseMessage.ResponseCode != "NoError" {
+	responseMessage := responseEnvelope.Body.GetItemResponse.ResponseMessages.GetItemResponseMessage
+	if responseMessage.ResponseClass != "Success" {
 		return nil, fmt.Errorf("EWS error: %s", responseMessage.ResponseCode)
 	}
 
-	return responseMessage.RootFolder.Items.CalendarItem, nil
+	if len(responseMessage.Items.CalendarItem) == 0 {
+		return nil, fmt.Errorf("no calendar item returned")
+	}
+
+	return &responseMessage.Items.CalendarItem[0], nil
 }
 
 // CalendarEvent represents a calendar event to be created
 type CalendarEvent struct {
 	Subject           string
 	Body              string
+	BodyType          BodyType // Format of Body; defaults to BodyTypeText when empty
 	Start             time.Time
 	End               time.Time
 	Location          string
 	IsAllDay          bool
 	RequiredAttendees []Attendee
 	OptionalAttendees []Attendee
-	SendInvites       bool // Controls whether meeting invitations are sent to attendees
+	SendInvites       bool                 // Controls whether meeting invitations are sent to attendees
+	ReminderIsSet     *bool                // Whether a reminder is set; defaults to true when nil
+	ReminderMinutes   *int                 // Minutes before start the reminder fires; defaults to 15 when nil
+	LegacyFreeBusy    LegacyFreeBusyStatus // Free/busy status shown to others; defaults to Busy when empty
+	Sensitivity       Sensitivity          // Optional sensitivity, e.g. SensitivityPrivate
+	Importance        Importance           // Optional importance, e.g. ImportanceHigh
+	Categories        []string             // Optional categories
+	Folder            CalendarFolder       // Calendar the event is created in; defaults to the mailbox's calendar
+
+	ExtendedProperties []ExtendedProperty // Optional extended properties, e.g. IDs of an external system
+}
+
+// defaultReminderMinutes is the reminder lead time used when CalendarEvent.ReminderMinutes is nil
+const defaultReminderMinutes = 15
+
+// reminder returns the reminder settings of the event, applying defaults
+func (e CalendarEvent) reminder() (bool, int) {
+	isSet := true
+	if e.ReminderIsSet != nil {
+		isSet = *e.ReminderIsSet
+	}
+	minutes := defaultReminderMinutes
+	if e.ReminderMinutes != nil {
+		minutes = *e.ReminderMinutes
+	}
+	return isSet, minutes
+}
+
+// freeBusy returns the free/busy status of the event, defaulting to Busy
+func (e CalendarEvent) freeBusy() LegacyFreeBusyStatus {
+	if e.LegacyFreeBusy == "" {
+		return Busy
+	}
+	return e.LegacyFreeBusy
+}
+
+// categoriesOf returns categories in their XML form, or nil if there are none
+func categoriesOf(categories []string) *ArrayOfStrings {
+	if len(categories) == 0 {
+		return nil
+	}
+	return &ArrayOfStrings{String: categories}
 }
 
 // CreateCalendarEvent creates a new calendar event
 func (c *EWSClient) CreateCalendarEvent(event CalendarEvent) (*string, error) {
-	// Format dates using timezone-aware methods
-	startStr := c.FormatDateWithoutTZ(event.Start)
-	endStr := c.FormatDateWithoutTZ(event.End)
+	return c.CreateCalendarEventContext(context.Background(), event)
+}
 
-	// Prepare the SOAP envelope
-	envelope := Envelope{
-		XMLNS:  "http://schemas.xmlsoap.org/soap/envelope/",
-		XMLNSt: "http://schemas.microsoft.com/exchange/services/2006/types",
-		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
-		Header: Header{
-			ServerVersionInfo: ServerVersionInfo{
-				Version: "Exchange2010",
-			},
-		},
-		Body: Body{
-			CreateItem: &CreateEventRequest{
-				// Set SendMeetingInvitations based on the SendInvites flag
-				SendMeetingInvitations: func() string {
-					if event.SendInvites {
-						return "SendToAllAndSaveCopy"
-					}
-					return "SendToNone"
-				}(),
-				SavedItemFolderId: SavedItemFolderId{
-					DistinguishedFolderId: DistinguishedFolderId{
-						Id: "calendar",
-					},
-				},
-				Items: CreateEventItems{
-					CalendarItem: CreateEventCalendarItem{
-						XMLNSt:  "http://schemas.microsoft.com/exchange/services/2006/types",
-						Subject: event.Subject,
-						Body: ItemBody{
-							BodyType: "Text",
-							Content:  event.Body,
-						},
-						ReminderIsSet:   true,
-						ReminderMinutes: 15,
-						Start:           startStr,
-						End:             endStr,
-						IsAllDayEvent:   event.IsAllDay,
-						LegacyFreeBusy:  Busy,
-						Location:        event.Location,
-					},
-				},
+// CreateCalendarEventContext is CreateCalendarEvent with a context
+func (c *EWSClient) CreateCalendarEventContext(ctx context.Context, event CalendarEvent) (*string, error) {
+	if err := CheckTimeZones(event.Start, event.End); err != nil {
+		return nil, err
+	}
+
+	body := Body{
+		CreateItem: &CreateEventRequest{
+			SendMeetingInvitations: event.sendMeetingInvitations(),
+			SavedItemFolderId:      event.Folder.savedItemFolderId(),
+			Items: CreateEventItems{
+				CalendarItem: []CreateEventCalendarItem{c.newCalendarItem(event)},
 			},
 		},
 	}
 
+	responseMessage, err := c.doItemRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the ID of the created event
+	if len(responseMessage.Items.CalendarItem) > 0 {
+		return &responseMessage.Items.CalendarItem[0].ItemId.Id, nil
+	}
+
+	return nil, fmt.Errorf("no item ID returned")
+}
+
+// sendMeetingInvitations returns the SendMeetingInvitations value matching the SendInvites flag
+func (e CalendarEvent) sendMeetingInvitations() string {
+	if e.SendInvites {
+		return "SendToAllAndSaveCopy"
+	}
+	return "SendToNone"
+}
+
+// newCalendarItem builds the CalendarItem element that creates the event
+func (c *EWSClient) newCalendarItem(event CalendarEvent) CreateEventCalendarItem {
+	// Format dates in the event's own location; the matching time zone is sent alongside
+	startStr := formatDateInLocation(event.Start)
+	endStr := formatDateInLocation(event.End)
+	reminderIsSet, reminderMinutes := event.reminder()
+
+	calendarItem := CreateEventCalendarItem{
+		XMLNSt:           "http://schemas.microsoft.com/exchange/services/2006/types",
+		Subject:          event.Subject,
+		Sensitivity:      event.Sensitivity,
+		Body:             itemBody(event.BodyType, event.Body),
+		Categories:       categoriesOf(event.Categories),
+		Importance:       event.Importance,
+		ReminderIsSet:    reminderIsSet,
+		ReminderMinutes:  reminderMinutes,
+		ExtendedProperty: event.ExtendedProperties,
+		Start:            startStr,
+		End:              endStr,
+		IsAllDayEvent:    event.IsAllDay,
+		LegacyFreeBusy:   event.freeBusy(),
+		Location:         event.Location,
+	}
+
+	// Send the time zones of the event so it lands at the right wall clock time
+	// regardless of the mailbox's own time zone
+	if isExchange2007(c.serverVersion()) {
+		meetingTimeZone := NewMeetingTimeZone(event.Start)
+		calendarItem.MeetingTimeZone = &meetingTimeZone
+	} else {
+		startTimeZone := NewTimeZoneDefinition(event.Start)
+		endTimeZone := NewTimeZoneDefinition(event.End)
+		calendarItem.StartTimeZone = &startTimeZone
+		calendarItem.EndTimeZone = &endTimeZone
+	}
+
 	// Add required attendees if present
 	if len(event.RequiredAttendees) > 0 {
 		requiredAttendees := RequiredAttendees{
@@ -279,7 +433,7 @@ func (c *EWSClient) CreateCalendarEvent(event CalendarEvent) (*string, error) {
 			})
 		}
 
-		envelope.Body.CreateItem.Items.CalendarItem.RequiredAttendees = &requiredAttendees
+		calendarItem.RequiredAttendees = &requiredAttendees
 	}
 
 	// Add optional attendees if present
@@ -298,122 +452,30 @@ func (c *EWSClient) CreateCalendarEvent(event CalendarEvent) (*string, error) {
 			})
 		}
 
-		envelope.Body.CreateItem.Items.CalendarItem.OptionalAttendees = &optionalAttendees
-	}
-
-	// Convert the envelope to XML
-	xmlData, err := xml.MarshalIndent(envelope, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("error marshalling request: %w", err)
-	}
-
-	// Create the HTTP request
-	req, err := http.NewRequest("POST", c.URL, bytes.NewReader(xmlData))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		calendarItem.OptionalAttendees = &optionalAttendees
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
-	req.SetBasicAuth(c.Username, c.Password)
-
-	// Send the request
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
-	// Read and parse the response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
-	}
-
-	// Parse the response
-	var responseEnvelope CreateItemResponseEnvelope
-	if err := xml.Unmarshal(body, &responseEnvelope); err != nil {
-		return nil, fmt.Errorf("error unmarshalling response: %w", err)
-	}
-
-	// Check response code
-	responseMessage := responseEnvelope.Body.CreateItemResponse.ResponseMessages.CreateItemResponseMessage
-	if responseMessage.ResponseClass != "Success" {
-		return nil, fmt.Errorf("EWS error: %s", responseMessage.ResponseCode)
-	}
-
-	// Return the ID of the created event
-	if len(responseMessage.Items.CalendarItem) > 0 {
-		return &responseMessage.Items.CalendarItem[0].ItemId.Id, nil
-	}
-
-	return nil, fmt.Errorf("no item ID returned")
+	return calendarItem
 }
 
 // DeleteCalendarEvent deletes a calendar event by its ID
 func (c *EWSClient) DeleteCalendarEvent(itemID string) error {
-	// Prepare the SOAP envelope
-	envelope := Envelope{
-		XMLNS:  "http://schemas.xmlsoap.org/soap/envelope/",
-		XMLNSt: "http://schemas.microsoft.com/exchange/services/2006/types",
-		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
-		Header: Header{
-			ServerVersionInfo: ServerVersionInfo{
-				Version: "Exchange2010",
-			},
-		},
-		Body: Body{
-			DeleteItem: &DeleteItemRequest{
-				XMLNSm:                   "http://schemas.microsoft.com/exchange/services/2006/messages",
-				DeleteType:               "HardDelete",
-				SendMeetingCancellations: "SendToAllAndSaveCopy",
-				ItemIds: DeleteItemIds{
-					ItemId: []ItemId{
-						{
-							Id: itemID,
-						},
-					},
-				},
-			},
-		},
-	}
-
-	// Convert the envelope to XML
-	xmlData, err := xml.MarshalIndent(envelope, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling request: %w", err)
-	}
-
-	// Create the HTTP request
-	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(xmlData))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
-	req.SetBasicAuth(c.Username, c.Password)
-
-	// Send the request
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
+	return c.DeleteCalendarEventContext(context.Background(), itemID)
+}
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+// DeleteCalendarEventContext is DeleteCalendarEvent with a context
+func (c *EWSClient) DeleteCalendarEventContext(ctx context.Context, itemID string) error {
+	body := Body{
+		DeleteItem: &DeleteItemRequest{
+			XMLNSm:                   "http://schemas.microsoft.com/exchange/services/2006/messages",
+			DeleteType:               "HardDelete",
+			SendMeetingCancellations: "SendToAllAndSaveCopy",
+			ItemIds:                  itemIdsOf([]string{itemID}),
+		},
 	}
 
-	return nil
+	_, err := c.doItemRequest(ctx, body)
+	return err
 }
 
 // EventUpdates represents updates to an existing calendar event
@@ -422,52 +484,85 @@ type EventUpdates struct {
 	End               *time.Time
 	Subject           *string
 	Body              *string
+	BodyType          BodyType // Format of Body; defaults to BodyTypeText when empty
 	LegacyFreeBusy    *LegacyFreeBusyStatus
 	Location          *string
+	IsAllDay          *bool
 	RequiredAttendees []Attendee
 	OptionalAttendees []Attendee
+	ReminderIsSet     *bool
+	ReminderMinutes   *int
+	Sensitivity       *Sensitivity
+	Importance        *Importance
+	Categories        []string // Replaces the existing categories when non-empty
+
+	ExtendedProperties       []ExtendedProperty // Extended properties to set
+	DeleteExtendedProperties []ExtendedFieldURI // Extended properties to remove
 }
 
 // UpdateCalendarEvent updates a calendar event by its ID
 func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) error {
-	// Prepare the SOAP envelope
-	envelope := Envelope{
-		XMLNS:  "http://schemas.xmlsoap.org/soap/envelope/",
-		XMLNSt: "http://schemas.microsoft.com/exchange/services/2006/types",
-		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
-		Header: Header{
-			ServerVersionInfo: ServerVersionInfo{
-				Version: "Exchange2010",
+	return c.UpdateCalendarEventContext(context.Background(), itemID, updates)
+}
+
+// UpdateCalendarEventContext is UpdateCalendarEvent with a context
+func (c *EWSClient) UpdateCalendarEventContext(ctx context.Context, itemID string, updates EventUpdates) error {
+	return c.updateCalendarEvent(ctx, itemID, updates, "SendToAllAndSaveCopy")
+}
+
+// updateCalendarEvent applies updates to the item, sending invitations or cancellations as
+// sendMeetingInvitationsOrCancellations says
+func (c *EWSClient) updateCalendarEvent(ctx context.Context, itemID string, updates EventUpdates, sendMeetingInvitationsOrCancellations string) error {
+	if err := updates.checkTimeZones(); err != nil {
+		return err
+	}
+
+	body := Body{
+		UpdateItem: &UpdateItemRequest{
+			XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ConflictResolution:     "AlwaysOverwrite",
+			SendMeetingInvitations: sendMeetingInvitationsOrCancellations,
+			MessageDisposition:     "SaveOnly",
+			ItemChanges: ItemChanges{
+				ItemChange: []ItemChange{c.itemChange(ItemId{Id: itemID}, updates)},
 			},
 		},
-		Body: Body{
-			UpdateItem: &UpdateItemRequest{
-				XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
-				ConflictResolution:     "AlwaysOverwrite",
-				SendMeetingInvitations: "SendToAllAndSaveCopy",
-				MessageDisposition:     "SaveOnly",
-				ItemChanges: ItemChanges{
-					ItemChange: ItemChange{
-						ItemId: ItemId{
-							Id: itemID,
-						},
-						Updates: Updates{
-							SetItemField: []SetItemField{},
-						},
-					},
-				},
-			},
+	}
+
+	_, err := c.doItemRequest(ctx, body)
+	return err
+}
+
+// checkTimeZones returns ErrUnknownTimeZone if the new start or end is in an unknown time zone
+func (u EventUpdates) checkTimeZones() error {
+	if u.Start != nil {
+		if err := CheckTimeZones(*u.Start); err != nil {
+			return err
+		}
+	}
+	if u.End != nil {
+		return CheckTimeZones(*u.End)
+	}
+	return nil
+}
+
+// itemChange builds the ItemChange that applies updates to the item
+func (c *EWSClient) itemChange(itemID ItemId, updates EventUpdates) ItemChange {
+	change := ItemChange{
+		ItemId: itemID,
+		Updates: Updates{
+			SetItemField: []SetItemField{},
 		},
 	}
 
 	// Add the updates
 	if updates.Start != nil {
-		// Format with timezone-aware method
-		startStr := c.FormatDateWithoutTZ(*updates.Start)
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		// Format in the location of the new start; its time zone is updated below
+		startStr := formatDateInLocation(*updates.Start)
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "calendar:Start",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -478,12 +573,12 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 	}
 
 	if updates.End != nil {
-		// Format with timezone-aware method
-		endStr := c.FormatDateWithoutTZ(*updates.End)
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		// Format in the location of the new end; its time zone is updated below
+		endStr := formatDateInLocation(*updates.End)
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "calendar:End",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -493,12 +588,60 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 		)
 	}
 
+	// Add time zone updates matching the new start and end
+	if isExchange2007(c.serverVersion()) {
+		if updates.Start != nil {
+			meetingTimeZone := NewMeetingTimeZone(*updates.Start)
+			change.Updates.SetItemField = append(
+				change.Updates.SetItemField,
+				SetItemField{
+					FieldURI: &FieldURI{
+						FieldURI: "calendar:MeetingTimeZone",
+					},
+					CalendarItem: UpdateCalendarItem{
+						MeetingTimeZone: &meetingTimeZone,
+					},
+				},
+			)
+		}
+	} else {
+		if updates.Start != nil {
+			startTimeZone := NewTimeZoneDefinition(*updates.Start)
+			change.Updates.SetItemField = append(
+				change.Updates.SetItemField,
+				SetItemField{
+					FieldURI: &FieldURI{
+						FieldURI: "calendar:StartTimeZone",
+					},
+					CalendarItem: UpdateCalendarItem{
+						StartTimeZone: &startTimeZone,
+					},
+				},
+			)
+		}
+
+		if updates.End != nil {
+			endTimeZone := NewTimeZoneDefinition(*updates.End)
+			change.Updates.SetItemField = append(
+				change.Updates.SetItemField,
+				SetItemField{
+					FieldURI: &FieldURI{
+						FieldURI: "calendar:EndTimeZone",
+					},
+					CalendarItem: UpdateCalendarItem{
+						EndTimeZone: &endTimeZone,
+					},
+				},
+			)
+		}
+	}
+
 	// Add Subject update if provided
 	if updates.Subject != nil {
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "item:Subject",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -510,17 +653,15 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 
 	// Add Body (notes) update if provided
 	if updates.Body != nil {
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		body := itemBody(updates.BodyType, *updates.Body)
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "item:Body",
 				},
 				CalendarItem: UpdateCalendarItem{
-					Body: &ItemBody{
-						BodyType: "Text",
-						Content:  *updates.Body,
-					},
+					Body: &body,
 				},
 			},
 		)
@@ -528,10 +669,10 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 
 	// Add LegacyFreeBusy update if provided
 	if updates.LegacyFreeBusy != nil {
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "calendar:LegacyFreeBusyStatus",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -541,12 +682,27 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 		)
 	}
 
+	// Add all-day update if provided
+	if updates.IsAllDay != nil {
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				FieldURI: &FieldURI{
+					FieldURI: "calendar:IsAllDayEvent",
+				},
+				CalendarItem: UpdateCalendarItem{
+					IsAllDayEvent: updates.IsAllDay,
+				},
+			},
+		)
+	}
+
 	// Add Location update if provided
 	if updates.Location != nil {
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "calendar:Location",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -556,6 +712,80 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 		)
 	}
 
+	// Add Sensitivity update if provided
+	if updates.Sensitivity != nil {
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				FieldURI: &FieldURI{
+					FieldURI: "item:Sensitivity",
+				},
+				CalendarItem: UpdateCalendarItem{
+					Sensitivity: updates.Sensitivity,
+				},
+			},
+		)
+	}
+
+	// Add Importance update if provided
+	if updates.Importance != nil {
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				FieldURI: &FieldURI{
+					FieldURI: "item:Importance",
+				},
+				CalendarItem: UpdateCalendarItem{
+					Importance: updates.Importance,
+				},
+			},
+		)
+	}
+
+	// Add Categories update if provided
+	if len(updates.Categories) > 0 {
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				FieldURI: &FieldURI{
+					FieldURI: "item:Categories",
+				},
+				CalendarItem: UpdateCalendarItem{
+					Categories: categoriesOf(updates.Categories),
+				},
+			},
+		)
+	}
+
+	// Add reminder updates if provided
+	if updates.ReminderIsSet != nil {
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				FieldURI: &FieldURI{
+					FieldURI: "item:ReminderIsSet",
+				},
+				CalendarItem: UpdateCalendarItem{
+					ReminderIsSet: updates.ReminderIsSet,
+				},
+			},
+		)
+	}
+
+	if updates.ReminderMinutes != nil {
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				FieldURI: &FieldURI{
+					FieldURI: "item:ReminderMinutesBeforeStart",
+				},
+				CalendarItem: UpdateCalendarItem{
+					ReminderMinutes: updates.ReminderMinutes,
+				},
+			},
+		)
+	}
+
 	// Add Required Attendees if provided
 	if len(updates.RequiredAttendees) > 0 {
 		requiredAttendees := RequiredAttendees{
@@ -572,10 +802,10 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 			}
 		}
 
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "calendar:RequiredAttendees",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -601,10 +831,10 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 			}
 		}
 
-		envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField = append(
-			envelope.Body.UpdateItem.ItemChanges.ItemChange.Updates.SetItemField,
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
 			SetItemField{
-				FieldURI: FieldURI{
+				FieldURI: &FieldURI{
 					FieldURI: "calendar:OptionalAttendees",
 				},
 				CalendarItem: UpdateCalendarItem{
@@ -614,52 +844,28 @@ func (c *EWSClient) UpdateCalendarEvent(itemID string, updates EventUpdates) err
 		)
 	}
 
-	// Convert the envelope to XML
-	xmlData, err := xml.MarshalIndent(envelope, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling request: %w", err)
-	}
-
-	// Create the HTTP request
-	req, err := http.NewRequest("POST", c.URL, bytes.NewReader(xmlData))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
-	req.SetBasicAuth(c.Username, c.Password)
-
-	// Send the request
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
-	// Read and parse the response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
-	}
-
-	// Parse the response
-	var responseEnvelope UpdateItemResponseEnvelope
-	if err := xml.Unmarshal(body, &responseEnvelope); err != nil {
-		return fmt.Errorf("error unmarshalling response: %w", err)
+	// Add extended property updates if provided
+	for _, property := range updates.ExtendedProperties {
+		path := property.ExtendedFieldURI
+		change.Updates.SetItemField = append(
+			change.Updates.SetItemField,
+			SetItemField{
+				ExtendedFieldURI: &path,
+				CalendarItem: UpdateCalendarItem{
+					ExtendedProperty: []ExtendedProperty{property},
+				},
+			},
+		)
 	}
 
-	// Check response code
-	responseMessage := responseEnvelope.Body.UpdateItemResponse.ResponseMessages.UpdateItemResponseMessage
-	if responseMessage.ResponseClass != "Success" {
-		return fmt.Errorf("EWS error: %s", responseMessage.ResponseCode)
+	for _, path := range updates.DeleteExtendedProperties {
+		change.Updates.DeleteItemField = append(
+			change.Updates.DeleteItemField,
+			DeleteItemField{
+				ExtendedFieldURI: &path,
+			},
+		)
 	}
 
-	return nil
+	return change
 }
@@ -0,0 +1,157 @@
+package ews
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CalendarItemType distinguishes single events from the parts of a recurring series
+type CalendarItemType string
+
+// CalendarItemType constants
+const (
+	CalendarItemTypeSingle          CalendarItemType = "Single"          // Event that does not recur
+	CalendarItemTypeOccurrence      CalendarItemType = "Occurrence"      // Unmodified occurrence of a recurring series
+	CalendarItemTypeException       CalendarItemType = "Exception"       // Occurrence that was modified
+	CalendarItemTypeRecurringMaster CalendarItemType = "RecurringMaster" // The series itself, returned by FindItems
+)
+
+// Event is a calendar item with its times parsed into the client's time zone. For all-day
+// events Start is midnight of the first day and End midnight after the last day, in the
+// client's time zone, whatever zone the event was created in.
+type Event struct {
+	ItemId          ItemId
+	Subject         string
+	Body            string
+	BodyType        BodyType
+	Start           time.Time
+	End             time.Time
+	Location        string
+	IsAllDay        bool
+	FreeBusy        LegacyFreeBusyStatus
+	Type            CalendarItemType
+	Sensitivity     Sensitivity
+	Importance      Importance
+	Categories      []string
+	ReminderIsSet   bool
+	ReminderMinutes int
+	HasAttachments  bool
+	Organizer       Attendee
+
+	ExtendedProperties []ItemExtendedProperty
+}
+
+// Duration returns the length of the event
+func (e Event) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// ToEvent converts a calendar item returned by EWS into an Event
+func (c *EWSClient) ToEvent(item CalendarItem) (Event, error) {
+	start, err := c.ParseDateTime(item.Start)
+	if err != nil {
+		return Event{}, fmt.Errorf("error parsing start of %q: %w", item.Subject, err)
+	}
+	end, err := c.ParseDateTime(item.End)
+	if err != nil {
+		return Event{}, fmt.Errorf("error parsing end of %q: %w", item.Subject, err)
+	}
+	return NewEvent(item, start, end, c.TimeZone), nil
+}
+
+// NewEvent returns the Event of a calendar item with the given parsed start and end, in loc.
+// Clients use it to implement ToEvent.
+func NewEvent(item CalendarItem, start, end time.Time, loc *time.Location) Event {
+	if item.IsAllDayEvent {
+		// The server returns all-day events as midnight in the zone they were created in
+		zone := item.StartTimeZone
+		if zone == nil {
+			zone = item.MeetingTimeZone
+		}
+		start = allDayMidnight(start, zone, loc)
+		end = allDayMidnight(end, zone, loc)
+	} else {
+		start = start.In(loc)
+		end = end.In(loc)
+	}
+
+	return Event{
+		ItemId:          item.ItemId,
+		Subject:         item.Subject,
+		Body:            item.Body.Content,
+		BodyType:        BodyType(item.Body.BodyType),
+		Start:           start,
+		End:             end,
+		Location:        item.Location,
+		IsAllDay:        item.IsAllDayEvent,
+		FreeBusy:        item.LegacyFreeBusy,
+		Type:            item.CalendarItemType,
+		Sensitivity:     item.Sensitivity,
+		Importance:      item.Importance,
+		Categories:      item.Categories,
+		ReminderIsSet:   item.ReminderIsSet,
+		ReminderMinutes: item.ReminderMinutesBeforeStart,
+		HasAttachments:  item.HasAttachments,
+		Organizer: Attendee{
+			Name:  item.Organizer.Mailbox.Name,
+			Email: item.Organizer.Mailbox.EmailAddress,
+		},
+		ExtendedProperties: item.ExtendedProperties,
+	}
+}
+
+// ToEvents converts calendar items returned by EWS into Events
+func (c *EWSClient) ToEvents(items []CalendarItem) ([]Event, error) {
+	events := make([]Event, 0, len(items))
+	for _, item := range items {
+		event, err := c.ToEvent(item)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// GetEvents retrieves the calendar items between the specified dates as Events
+func (c *EWSClient) GetEvents(ctx context.Context, startDate, endDate time.Time) ([]Event, error) {
+	items, err := c.GetCalendarItemsInFolder(ctx, CalendarFolder{}, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return c.ToEvents(items)
+}
+
+// ToCalendarEvent returns a CalendarEvent that creates a copy of e, e.g. in another calendar
+func (e Event) ToCalendarEvent() CalendarEvent {
+	return CalendarEvent{
+		Subject:         e.Subject,
+		Body:            e.Body,
+		BodyType:        e.BodyType,
+		Start:           e.Start,
+		End:             e.End,
+		Location:        e.Location,
+		IsAllDay:        e.IsAllDay,
+		ReminderIsSet:   &e.ReminderIsSet,
+		ReminderMinutes: &e.ReminderMinutes,
+		LegacyFreeBusy:  e.FreeBusy,
+		Sensitivity:     e.Sensitivity,
+		Importance:      e.Importance,
+		Categories:      e.Categories,
+	}
+}
+
+// allDayMidnight returns the midnight in loc of the day an all-day event boundary t falls on.
+// The day is read in the event's own zone when it is known. Otherwise t is rounded to the
+// nearest midnight of loc, which finds the right day for zones less than 12 hours apart.
+func allDayMidnight(t time.Time, zone *TimeZoneInfo, loc *time.Location) time.Time {
+	if zone != nil {
+		if eventLoc, err := zone.Location(); err == nil {
+			t = t.In(eventLoc)
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+	}
+	t = t.In(loc).Add(12 * time.Hour)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
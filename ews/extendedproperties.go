@@ -0,0 +1,105 @@
+package ews
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// MAPI property types of extended properties
+const (
+	PropertyTypeString      = "String"
+	PropertyTypeStringArray = "StringArray"
+	PropertyTypeInteger     = "Integer"
+	PropertyTypeLong        = "Long"
+	PropertyTypeBoolean     = "Boolean"
+	PropertyTypeSystemTime  = "SystemTime"
+	PropertyTypeBinary      = "Binary"
+)
+
+// NamedProperty returns the path of a named property in a custom property set identified by its GUID.
+// Applications usually define one property set for all of their properties.
+func NamedProperty(propertySetID, name, propertyType string) ExtendedFieldURI {
+	return ExtendedFieldURI{PropertySetId: propertySetID, PropertyName: name, PropertyType: propertyType}
+}
+
+// PublicStringsProperty returns the path of a named property in the PublicStrings property set
+func PublicStringsProperty(name, propertyType string) ExtendedFieldURI {
+	return ExtendedFieldURI{DistinguishedPropertySetId: "PublicStrings", PropertyName: name, PropertyType: propertyType}
+}
+
+// DistinguishedProperty returns the path of a property identified by its ID in a well-known
+// property set, such as "Appointment" or "Common"
+func DistinguishedProperty(propertySetID string, id int, propertyType string) ExtendedFieldURI {
+	return ExtendedFieldURI{DistinguishedPropertySetId: propertySetID, PropertyId: strconv.Itoa(id), PropertyType: propertyType}
+}
+
+// PropertyTag returns the path of a MAPI property identified by its tag, e.g. 0x0E08 for the item size
+func PropertyTag(tag uint16, propertyType string) ExtendedFieldURI {
+	return ExtendedFieldURI{PropertyTag: "0x" + strconv.FormatUint(uint64(tag), 16), PropertyType: propertyType}
+}
+
+// Matches reports whether f and other identify the same property. Property set GUIDs, names
+// and tags are compared case-insensitively, as the server may change their case.
+func (f ExtendedFieldURI) Matches(other ExtendedFieldURI) bool {
+	return strings.EqualFold(f.DistinguishedPropertySetId, other.DistinguishedPropertySetId) &&
+		strings.EqualFold(f.PropertySetId, other.PropertySetId) &&
+		strings.EqualFold(f.PropertyTag, other.PropertyTag) &&
+		f.PropertyName == other.PropertyName &&
+		f.PropertyId == other.PropertyId
+}
+
+// ExtendedProperty is the value of an extended property set on an item.
+// Values is used for array property types, Value for all others.
+type ExtendedProperty struct {
+	ExtendedFieldURI ExtendedFieldURI
+	Value            string
+	Values           []string
+}
+
+// NewExtendedProperty returns an extended property with a single value
+func NewExtendedProperty(path ExtendedFieldURI, value string) ExtendedProperty {
+	return ExtendedProperty{ExtendedFieldURI: path, Value: value}
+}
+
+func (p ExtendedProperty) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(e, start, func() error {
+		if err := p.ExtendedFieldURI.encodePath(e); err != nil {
+			return err
+		}
+		// An empty Value is still sent, so a property can be set to the empty string
+		if p.Values == nil {
+			return e.EncodeElement(p.Value, tElement("Value"))
+		}
+		return e.EncodeElement(struct {
+			Value []string `xml:"t:Value"`
+		}{p.Values}, tElement("Values"))
+	})
+}
+
+// ItemExtendedProperty is an extended property returned on an item
+type ItemExtendedProperty struct {
+	ExtendedFieldURI ExtendedFieldURI `xml:"ExtendedFieldURI"`
+	Value            string           `xml:"Value"`
+	Values           []string         `xml:"Values>Value"`
+}
+
+// ExtendedProperty returns the extended property of the item identified by path. Extended properties
+// are only returned when they are requested in the AdditionalProperties of the item shape.
+func (item CalendarItem) ExtendedProperty(path ExtendedFieldURI) (ItemExtendedProperty, bool) {
+	return findExtendedProperty(item.ExtendedProperties, path)
+}
+
+// ExtendedProperty returns the extended property of the item identified by path
+func (item Item) ExtendedProperty(path ExtendedFieldURI) (ItemExtendedProperty, bool) {
+	return findExtendedProperty(item.ExtendedProperties, path)
+}
+
+func findExtendedProperty(properties []ItemExtendedProperty, path ExtendedFieldURI) (ItemExtendedProperty, bool) {
+	for _, property := range properties {
+		if property.ExtendedFieldURI.Matches(path) {
+			return property, true
+		}
+	}
+	return ItemExtendedProperty{}, false
+}
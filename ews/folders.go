@@ -0,0 +1,88 @@
+package ews
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CalendarFolder identifies the calendar an operation reads from or writes to.
+// The zero value is the default calendar of the caller's own mailbox.
+type CalendarFolder struct {
+	FolderId        string // ID of a specific folder, e.g. as returned by FindCalendarFolder
+	ChangeKey       string // Optional change key of FolderId
+	DistinguishedId string // Well-known folder used when FolderId is empty; defaults to "calendar"
+	Mailbox         string // SMTP address of another mailbox holding DistinguishedId, e.g. a manager's calendar
+}
+
+// SharedCalendar returns the default calendar of another mailbox the caller has been granted access to
+func SharedCalendar(mailbox string) CalendarFolder {
+	return CalendarFolder{Mailbox: mailbox}
+}
+
+// folderIds returns the FolderId or DistinguishedFolderId element identifying the folder
+func (f CalendarFolder) folderIds() (*FolderId, *DistinguishedFolderId) {
+	if f.FolderId != "" {
+		return &FolderId{Id: f.FolderId, ChangeKey: f.ChangeKey}, nil
+	}
+
+	distinguished := &DistinguishedFolderId{Id: f.DistinguishedId}
+	if distinguished.Id == "" {
+		distinguished.Id = "calendar"
+	}
+	if f.Mailbox != "" {
+		distinguished.Mailbox = &FolderMailbox{EmailAddress: f.Mailbox}
+	}
+	return nil, distinguished
+}
+
+func (f CalendarFolder) parentFolderIds() ParentFolderIds {
+	folderID, distinguished := f.folderIds()
+	return ParentFolderIds{FolderId: folderID, DistinguishedFolderId: distinguished}
+}
+
+func (f CalendarFolder) savedItemFolderId() SavedItemFolderId {
+	folderID, distinguished := f.folderIds()
+	return SavedItemFolderId{FolderId: folderID, DistinguishedFolderId: distinguished}
+}
+
+func (f CalendarFolder) targetFolderId() TargetFolderId {
+	folderID, distinguished := f.folderIds()
+	return TargetFolderId{FolderId: folderID, DistinguishedFolderId: distinguished}
+}
+
+// FindCalendarFolder locates a calendar folder by its display name, searching every folder of the
+// caller's mailbox, or of mailbox when it is not empty. The comparison is case-insensitive and the
+// first match is returned.
+func (c *EWSClient) FindCalendarFolder(ctx context.Context, displayName, mailbox string) (*CalendarFolder, error) {
+	root := CalendarFolder{DistinguishedId: "msgfolderroot", Mailbox: mailbox}
+	body := Body{
+		FindFolder: &FindFolderRequest{
+			XMLNSm:    "http://schemas.microsoft.com/exchange/services/2006/messages",
+			Traversal: "Deep",
+			FolderShape: FolderShape{
+				BaseShape: "Default",
+			},
+			ParentFolderIds: root.parentFolderIds(),
+		},
+	}
+
+	var responseEnvelope FindFolderResponseEnvelope
+	if err := c.doRequest(ctx, body, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	// Check response code
+	responseMessage := responseEnvelope.Body.FindFolderResponse.ResponseMessages.FindFolderResponseMessage
+	if responseMessage.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error: %s", responseMessage.ResponseCode)
+	}
+
+	for _, folder := range responseMessage.RootFolder.Folders.CalendarFolder {
+		if strings.EqualFold(folder.DisplayName, displayName) {
+			return &CalendarFolder{FolderId: folder.FolderId.Id, ChangeKey: folder.FolderId.ChangeKey}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("calendar folder %q not found", displayName)
+}
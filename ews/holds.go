@@ -0,0 +1,238 @@
+package ews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// holdPropertySetID is the property set of the extended properties tagging holds
+const holdPropertySetID = "3d5c8f0e-7b2a-4c61-9e4d-a18f6b7c2e95"
+
+// HoldGroupProperty and HoldExpiryProperty tag the tentative events placed by PlaceHolds with their
+// group and the time after which they may be deleted
+var (
+	HoldGroupProperty  = NamedProperty(holdPropertySetID, "HoldGroup", PropertyTypeString)
+	HoldExpiryProperty = NamedProperty(holdPropertySetID, "HoldExpiry", PropertyTypeSystemTime)
+)
+
+// HoldShape returns the start, end and hold tags of items, enough to read them as holds
+var HoldShape = Shape{
+	BaseShape:            BaseShapeIdOnly,
+	AdditionalProperties: []PropertyPath{FieldStart, FieldEnd, HoldGroupProperty, HoldExpiryProperty},
+}
+
+// ErrHoldExpired is returned by ConfirmHold when the hold has expired or was deleted
+var ErrHoldExpired = errors.New("hold has expired")
+
+// Hold is a tentative event blocking a time offered to someone until they confirm it or it expires
+type Hold struct {
+	ItemID    string
+	ChangeKey string // Version of the hold when it was read; a hold changed since is not deleted
+	Group     string // Holds offered together, e.g. the interview times offered to one candidate
+	TimeSlot
+	Expires time.Time
+}
+
+// HoldProperties returns the extended properties tagging an event as a hold of the group
+func HoldProperties(group string, expires time.Time) []ExtendedProperty {
+	return []ExtendedProperty{
+		NewExtendedProperty(HoldGroupProperty, group),
+		NewExtendedProperty(HoldExpiryProperty, expires.UTC().Format(time.RFC3339)),
+	}
+}
+
+// HoldsRestriction matches the holds of the group
+func HoldsRestriction(group string) SearchExpression {
+	return IsEqualTo(HoldGroupProperty, group)
+}
+
+// ExpiredHoldsRestriction matches the holds that expired at or before now
+func ExpiredHoldsRestriction(now time.Time) SearchExpression {
+	return IsLessThanOrEqualTo(HoldExpiryProperty, now)
+}
+
+// ReadHold reads the group and expiry of a hold from the extended properties of its item,
+// returning false when the item is not a hold
+func ReadHold(properties []ItemExtendedProperty) (string, time.Time, bool) {
+	group, ok := findExtendedProperty(properties, HoldGroupProperty)
+	if !ok {
+		return "", time.Time{}, false
+	}
+	expiry, ok := findExtendedProperty(properties, HoldExpiryProperty)
+	if !ok {
+		return "", time.Time{}, false
+	}
+	expires, err := time.Parse(time.RFC3339, expiry.Value)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return group.Value, expires, true
+}
+
+// PlaceHolds blocks each slot with a tentative copy of the event in the calendar, tagged with the
+// group and expiry. Holds have no attendees and are created without invitations, whatever the
+// attendees and SendInvites of event; add the attendees when confirming a hold with ConfirmHold.
+// Holds are always placed in the mailbox's calendar, so event.Folder is ignored. If a hold cannot be
+// created, those already created are deleted again.
+// Tentative events only block time for availability options including Tentative in BlockingStatuses.
+func (c *EWSClient) PlaceHolds(ctx context.Context, group string, event CalendarEvent, slots []TimeSlot, expires time.Time) ([]Hold, error) {
+	if group == "" {
+		return nil, fmt.Errorf("hold group is required")
+	}
+
+	events := make([]CalendarEvent, 0, len(slots))
+	for _, slot := range slots {
+		hold := event
+		hold.Start, hold.End = slot.Start, slot.End
+		hold.LegacyFreeBusy = Tentative
+		hold.RequiredAttendees, hold.OptionalAttendees = nil, nil
+		hold.SendInvites = false
+		hold.Folder = CalendarFolder{}
+		hold.ExtendedProperties = append(slices.Clip(event.ExtendedProperties), HoldProperties(group, expires)...)
+		events = append(events, hold)
+	}
+
+	results, err := c.CreateCalendarEvents(ctx, events, 0)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	holds := make([]Hold, 0, len(results))
+	for i, result := range results {
+		switch {
+		case result.ItemId != nil:
+			holds = append(holds, Hold{ItemID: result.ItemId.Id, ChangeKey: result.ItemId.ChangeKey, Group: group, TimeSlot: slots[i], Expires: expires})
+		case err == nil:
+			errs = append(errs, fmt.Errorf("error placing hold at %s: %w", slots[i].Start.Format(time.RFC3339), result.Err))
+		}
+	}
+	if len(errs) > 0 {
+		if _, err := c.deleteHolds(ctx, holds); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting placed holds: %w", err))
+		}
+		return nil, errors.Join(errs...)
+	}
+	return holds, nil
+}
+
+// GetHolds returns the holds of the group, including expired holds not deleted yet
+func (c *EWSClient) GetHolds(ctx context.Context, group string) ([]Hold, error) {
+	return c.findHolds(ctx, HoldsRestriction(group))
+}
+
+// ConfirmHold turns the hold into a meeting: it applies the updates, e.g. the subject and attendees,
+// marks the time busy, removes the hold tags and deletes the other holds of its group.
+// sendMeetingInvitations controls the invitations sent to the attendees, e.g. "SendToAllAndSaveCopy".
+// It returns ErrHoldExpired if the hold has expired or no longer exists.
+func (c *EWSClient) ConfirmHold(ctx context.Context, hold Hold, updates EventUpdates, sendMeetingInvitations string) error {
+	holds, err := c.GetHolds(ctx, hold.Group)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(holds, func(h Hold) bool { return h.ItemID == hold.ItemID })
+	if i < 0 || !holds[i].Expires.After(time.Now()) {
+		return ErrHoldExpired
+	}
+
+	if updates.LegacyFreeBusy == nil {
+		busy := Busy
+		updates.LegacyFreeBusy = &busy
+	}
+	updates.DeleteExtendedProperties = append(slices.Clip(updates.DeleteExtendedProperties), HoldGroupProperty, HoldExpiryProperty)
+	err = c.updateCalendarEvent(ctx, hold.ItemID, updates, sendMeetingInvitations)
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) && responseErr.ResponseCode == "ErrorItemNotFound" {
+		// Deleted by a reaper since it was read
+		return ErrHoldExpired
+	}
+	if err != nil {
+		return err
+	}
+
+	others := slices.Delete(holds, i, i+1)
+	if _, err := c.deleteHolds(ctx, others); err != nil {
+		return fmt.Errorf("error releasing other holds: %w", err)
+	}
+	return nil
+}
+
+// ReleaseHolds deletes the holds of the group, e.g. when none of the offered times suits
+func (c *EWSClient) ReleaseHolds(ctx context.Context, group string) error {
+	holds, err := c.GetHolds(ctx, group)
+	if err != nil {
+		return err
+	}
+	_, err = c.deleteHolds(ctx, holds)
+	return err
+}
+
+// DeleteExpiredHolds deletes the expired holds of the calendar and returns how many were deleted
+func (c *EWSClient) DeleteExpiredHolds(ctx context.Context) (int, error) {
+	holds, err := c.findHolds(ctx, ExpiredHoldsRestriction(time.Now()))
+	if err != nil {
+		return 0, err
+	}
+	return c.deleteHolds(ctx, holds)
+}
+
+// findHolds returns every hold matching the restriction. All pages are read before any is changed,
+// as deleting items would shift the offsets of later pages.
+func (c *EWSClient) findHolds(ctx context.Context, restriction SearchExpression) ([]Hold, error) {
+	var holds []Hold
+	opts := FindItemsOptions{Restriction: restriction, Shape: HoldShape}
+	for item, err := range c.FindCalendarItemsSeq(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		group, expires, ok := ReadHold(item.ExtendedProperties)
+		if !ok {
+			continue
+		}
+		start, err := c.ParseDateTime(item.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := c.ParseDateTime(item.End)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, Hold{ItemID: item.ItemId.Id, ChangeKey: item.ItemId.ChangeKey, Group: group, TimeSlot: TimeSlot{Start: start, End: end}, Expires: expires})
+	}
+	return holds, nil
+}
+
+// deleteHolds deletes the holds that have not changed since they were read, and returns how many
+// were deleted. The change key keeps a reaper from deleting a hold confirmed after it was read.
+func (c *EWSClient) deleteHolds(ctx context.Context, holds []Hold) (int, error) {
+	if len(holds) == 0 {
+		return 0, nil
+	}
+	itemIds := make([]ItemId, 0, len(holds))
+	for _, hold := range holds {
+		itemIds = append(itemIds, ItemId{Id: hold.ItemID, ChangeKey: hold.ChangeKey})
+	}
+
+	results, err := c.deleteItems(ctx, itemIds, "HardDelete", "SendToNone", 0)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	var errs []error
+	for _, result := range results {
+		var responseErr *ResponseError
+		switch {
+		case errors.As(result.Err, &responseErr) && responseErr.ResponseCode == "ErrorItemNotFound":
+			// Already deleted, e.g. by a reaper running at the same time
+		case errors.As(result.Err, &responseErr) && responseErr.ResponseCode == "ErrorIrresolvableConflict":
+			// Changed since it was read, e.g. confirmed by ConfirmHold
+		case result.Err != nil:
+			errs = append(errs, result.Err)
+		default:
+			deleted++
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
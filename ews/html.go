@@ -0,0 +1,128 @@
+package ews
+
+import (
+	"html"
+	"strings"
+)
+
+// blockTags are HTML elements that start a new line in the plain-text rendering
+var blockTags = map[string]bool{
+	"address": true, "article": true, "blockquote": true, "div": true, "dl": true, "dt": true, "dd": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "tr": true, "ul": true,
+}
+
+// HTMLToText converts an HTML body into a plain-text approximation.
+// Block elements and <br> become line breaks, list items are prefixed with "- ",
+// script and style contents are dropped and HTML entities are decoded.
+// It is intended as a fallback for clients that cannot render HTML, not as a full renderer.
+func HTMLToText(src string) string {
+	var out strings.Builder
+	skip := "" // name of the element whose content is being skipped (script/style)
+
+	for len(src) > 0 {
+		lt := strings.IndexByte(src, '<')
+		if lt < 0 {
+			if skip == "" {
+				out.WriteString(html.UnescapeString(src))
+			}
+			break
+		}
+		if lt > 0 && skip == "" {
+			out.WriteString(html.UnescapeString(src[:lt]))
+		}
+		src = src[lt:]
+
+		// Comments may contain '>' so they need their own terminator
+		if strings.HasPrefix(src, "<!--") {
+			end := strings.Index(src, "-->")
+			if end < 0 {
+				break
+			}
+			src = src[end+3:]
+			continue
+		}
+
+		gt := strings.IndexByte(src, '>')
+		if gt < 0 {
+			break
+		}
+		tag := src[1:gt]
+		src = src[gt+1:]
+
+		closing := strings.HasPrefix(tag, "/")
+		name := strings.ToLower(strings.TrimLeft(tag, "/"))
+		if i := strings.IndexAny(name, " \t\r\n/"); i >= 0 {
+			name = name[:i]
+		}
+
+		if skip != "" {
+			if closing && name == skip {
+				skip = ""
+			}
+			continue
+		}
+
+		switch {
+		case name == "script" || name == "style" || name == "head":
+			if !closing {
+				skip = name
+			}
+		case name == "br":
+			out.WriteString("\n")
+		case name == "li":
+			if !closing {
+				out.WriteString("\n- ")
+			}
+		case name == "td" || name == "th":
+			if closing {
+				out.WriteString("\t")
+			}
+		case blockTags[name]:
+			out.WriteString("\n")
+		}
+	}
+
+	return normalizeText(out.String())
+}
+
+// normalizeText collapses runs of whitespace within lines, trims each line and
+// limits consecutive blank lines to one
+func normalizeText(s string) string {
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	lines := strings.Split(s, "\n")
+
+	var out []string
+	blank := false
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// TextBody returns the item body as plain text, converting it from HTML when needed
+func (item CalendarItem) TextBody() string {
+	if BodyType(item.Body.BodyType) == BodyTypeHTML {
+		return HTMLToText(item.Body.Content)
+	}
+	return item.Body.Content
+}
+
+// itemBody builds an ItemBody, defaulting to a plain text body when no type is given
+func itemBody(bodyType BodyType, content string) ItemBody {
+	if bodyType == "" {
+		bodyType = BodyTypeText
+	}
+	return ItemBody{BodyType: string(bodyType), Content: content}
+}
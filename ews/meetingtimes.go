@@ -0,0 +1,177 @@
+package ews
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MeetingAttendee is a mailbox that should attend a meeting searched for with FindMeetingTimes,
+// such as a colleague or a meeting room
+type MeetingAttendee struct {
+	Email        string
+	Optional     bool          // Busy optional attendees lower the score of a slot instead of excluding it
+	Room         bool          // Meeting room or other resource, which GetMeetingSuggestions treats as such
+	WorkingHours *WorkingHours // Overrides the working hours returned by the busy source; nil keeps them
+}
+
+// MeetingRequest describes the meeting FindMeetingTimes looks for
+type MeetingRequest struct {
+	Attendees  []MeetingAttendee
+	Start      time.Time     // Start of the searched range
+	End        time.Time     // End of the searched range
+	Duration   time.Duration // Length of the meeting
+	MaxResults int           // Maximum number of slots returned; zero returns all
+
+	// Options apply to every attendee: Step sets the interval between candidate starts, BlockingStatuses
+	// the statuses that count as busy, and WorkingHours and ExcludedDates the organizer's own limits.
+	// Each attendee's working hours are applied in addition, in their own time zone, as are the
+	// buffers and daily cap to their own events.
+	Options AvailabilityOptions
+}
+
+// MeetingSlot is a candidate time for a meeting returned by FindMeetingTimes
+type MeetingSlot struct {
+	TimeSlot
+	Score     float64  // 1 when every attendee is free, down to 0 when every optional attendee has a conflict
+	Conflicts []string // Optional attendees who are busy or outside their working hours during the slot
+}
+
+// AttendeeBusy is the busy time of one mailbox returned by a BusySource
+type AttendeeBusy struct {
+	Email        string
+	Busy         []BusyInterval
+	WorkingHours *WorkingHours // nil when the mailbox's working hours are unknown
+	Err          error         // Error reading the mailbox's busy time
+}
+
+// BusySource reads the busy time of mailboxes, e.g. through GetUserAvailability or by reading each
+// calendar. Results are in the order of emails.
+type BusySource interface {
+	BusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]AttendeeBusy, error)
+}
+
+// FreeBusySource is a BusySource reading free/busy information with GetUserAvailability, which only
+// needs free/busy permission on the mailboxes. The server limits the range to 42 days.
+type FreeBusySource struct {
+	Client  *EWSClient
+	Options FreeBusyOptions
+}
+
+// BusyTimes implements BusySource
+func (s FreeBusySource) BusyTimes(ctx context.Context, emails []string, start, end time.Time) ([]AttendeeBusy, error) {
+	availability, err := s.Client.GetUserAvailability(ctx, emails, start, end, s.Options)
+	if err != nil {
+		return nil, err
+	}
+	return AttendeeBusyTimes(availability), nil
+}
+
+// AttendeeBusyTimes converts the results of GetUserAvailability for a BusySource
+func AttendeeBusyTimes(availability []AttendeeAvailability) []AttendeeBusy {
+	busy := make([]AttendeeBusy, 0, len(availability))
+	for _, attendee := range availability {
+		busy = append(busy, AttendeeBusy{
+			Email:        attendee.Email,
+			Busy:         attendee.BusyIntervals(),
+			WorkingHours: attendee.WorkingHours,
+			Err:          attendee.Err,
+		})
+	}
+	return busy
+}
+
+// FindMeetingTimes returns the slots in which every required attendee is free and within their working
+// hours, ranked by score and then by start time. Slots in which optional attendees are busy are kept
+// with a lower score. It fails if the busy time of any attendee cannot be read.
+func FindMeetingTimes(ctx context.Context, source BusySource, req MeetingRequest) ([]MeetingSlot, error) {
+	emails := make([]string, 0, len(req.Attendees))
+	for _, attendee := range req.Attendees {
+		emails = append(emails, attendee.Email)
+	}
+
+	from, to := req.Options.SearchRange(req.Start, req.End)
+	busyTimes, err := source.BusyTimes(ctx, emails, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(busyTimes) != len(req.Attendees) {
+		return nil, fmt.Errorf("expected busy time of %d attendees, got %d", len(req.Attendees), len(busyTimes))
+	}
+
+	var required []BusyInterval
+	var optional [][]BusyInterval
+	var optionalEmails []string
+	for i, attendee := range req.Attendees {
+		busy := busyTimes[i]
+		if busy.Err != nil {
+			return nil, fmt.Errorf("error reading busy time of %s: %w", attendee.Email, busy.Err)
+		}
+
+		hours := busy.WorkingHours
+		if attendee.WorkingHours != nil {
+			hours = attendee.WorkingHours
+		}
+		// Buffers and the daily cap apply to each attendee's own events, in their own time zone
+		attendeeOpts := req.Options
+		attendeeOpts.WorkingHours = hours
+		blocking := attendeeOpts.blocking(req.Start, req.End, busy.Busy)
+
+		if attendee.Optional {
+			optional = append(optional, MergeBusyIntervals(blocking))
+			optionalEmails = append(optionalEmails, attendee.Email)
+		} else {
+			required = append(required, blocking...)
+		}
+	}
+
+	required = append(required, req.Options.closedIntervals(req.Start, req.End)...)
+	required = append(required, req.Options.noticeIntervals(req.Start, req.End)...)
+	var slots []MeetingSlot
+	for _, slot := range freeSlots(req.Start, req.End, req.Duration, required, req.Options) {
+		candidate := MeetingSlot{TimeSlot: slot, Score: 1}
+		for i, blocking := range optional {
+			if overlapsAny(blocking, slot) {
+				candidate.Conflicts = append(candidate.Conflicts, optionalEmails[i])
+			}
+		}
+		if len(optional) > 0 {
+			candidate.Score = 1 - float64(len(candidate.Conflicts))/float64(len(optional))
+		}
+		slots = append(slots, candidate)
+	}
+
+	// The sort is stable, so slots with equal scores stay in start order
+	slices.SortStableFunc(slots, func(a, b MeetingSlot) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	if req.MaxResults > 0 && len(slots) > req.MaxResults {
+		slots = slots[:req.MaxResults]
+	}
+
+	return slots, nil
+}
+
+// overlapsAny reports whether any of the sorted, merged intervals overlaps the slot
+func overlapsAny(intervals []BusyInterval, slot TimeSlot) bool {
+	i, _ := slices.BinarySearchFunc(intervals, slot.Start, func(interval BusyInterval, t time.Time) int {
+		if interval.End.After(t) {
+			return 1
+		}
+		return -1
+	})
+	return i < len(intervals) && intervals[i].Start.Before(slot.End)
+}
+
+// FindMeetingTimes finds times at which the attendees can meet, reading their free/busy information
+// with GetUserAvailability. See the package function FindMeetingTimes for other busy sources.
+func (c *EWSClient) FindMeetingTimes(ctx context.Context, req MeetingRequest) ([]MeetingSlot, error) {
+	return FindMeetingTimes(ctx, FreeBusySource{Client: c}, req)
+}
@@ -0,0 +1,66 @@
+package ews
+
+import (
+	"context"
+)
+
+// MoveItem moves an item of any type, such as an event or a message, to another folder,
+// e.g. CalendarFolder{DistinguishedId: "deleteditems"} or a calendar returned by FindCalendarFolder.
+// Moving an item changes its ID; the new ID is returned, or nil if the server did not report it,
+// as happens when the item is moved to another mailbox.
+func (c *EWSClient) MoveItem(ctx context.Context, itemID string, to CalendarFolder) (*ItemId, error) {
+	body := Body{
+		MoveItem: &MoveItemRequest{
+			XMLNSm:           "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ToFolderId:       to.targetFolderId(),
+			ItemIds:          itemIdsOf([]string{itemID}),
+			ReturnNewItemIds: c.returnNewItemIds(),
+		},
+	}
+	return c.moveOrCopyItem(ctx, body)
+}
+
+// CopyItem copies an item of any type to another folder and returns the ID of the copy,
+// or nil if the server did not report it
+func (c *EWSClient) CopyItem(ctx context.Context, itemID string, to CalendarFolder) (*ItemId, error) {
+	body := Body{
+		CopyItem: &CopyItemRequest{
+			XMLNSm:           "http://schemas.microsoft.com/exchange/services/2006/messages",
+			ToFolderId:       to.targetFolderId(),
+			ItemIds:          itemIdsOf([]string{itemID}),
+			ReturnNewItemIds: c.returnNewItemIds(),
+		},
+	}
+	return c.moveOrCopyItem(ctx, body)
+}
+
+func (c *EWSClient) moveOrCopyItem(ctx context.Context, body Body) (*ItemId, error) {
+	responseMessage, err := c.doItemRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return responseMessage.Items.firstItemId(), nil
+}
+
+// returnNewItemIds asks the server to return the IDs of moved and copied items. The element was
+// added in Exchange 2010 SP1; earlier versions always return them and reject the element.
+func (c *EWSClient) returnNewItemIds() *bool {
+	if isExchange2007(c.serverVersion()) || c.serverVersion() == "Exchange2010" {
+		return nil
+	}
+	returnIds := true
+	return &returnIds
+}
+
+// firstItemId returns the ID of the first item of any type, or nil if there are no items
+func (items Items) firstItemId() *ItemId {
+	switch {
+	case len(items.CalendarItem) > 0:
+		return &items.CalendarItem[0].ItemId
+	case len(items.Message) > 0:
+		return &items.Message[0].ItemId
+	case len(items.Item) > 0:
+		return &items.Item[0].ItemId
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package ews
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PropertyPath identifies a property in restrictions and sort orders
+type PropertyPath interface {
+	encodePath(e *xml.Encoder) error
+}
+
+// Field returns the path of a schema property such as "item:Subject" or "calendar:Start"
+func Field(uri string) FieldURI {
+	return FieldURI{FieldURI: uri}
+}
+
+func (f FieldURI) encodePath(e *xml.Encoder) error {
+	return e.EncodeElement(f, xml.StartElement{Name: xml.Name{Local: "t:FieldURI"}})
+}
+
+// Commonly searched and sorted properties
+var (
+	FieldSubject          = Field("item:Subject")
+	FieldBody             = Field("item:Body")
+	FieldCategories       = Field("item:Categories")
+	FieldItemClass        = Field("item:ItemClass")
+	FieldImportance       = Field("item:Importance")
+	FieldSensitivity      = Field("item:Sensitivity")
+	FieldDateTimeCreated  = Field("item:DateTimeCreated")
+	FieldDateTimeReceived = Field("item:DateTimeReceived")
+	FieldHasAttachments   = Field("item:HasAttachments")
+	FieldStart            = Field("calendar:Start")
+	FieldEnd              = Field("calendar:End")
+	FieldLocation         = Field("calendar:Location")
+	FieldLegacyFreeBusy   = Field("calendar:LegacyFreeBusyStatus")
+	FieldIsAllDayEvent    = Field("calendar:IsAllDayEvent")
+	FieldFrom             = Field("message:From")
+	FieldSender           = Field("message:Sender")
+	FieldIsRead           = Field("message:IsRead")
+)
+
+// SearchExpression is a search filter built with IsEqualTo, Contains, And, Or, Not and the other
+// constructors in this file. It is sent as the Restriction of a FindItem request.
+type SearchExpression interface {
+	encodeExpression(e *xml.Encoder) error
+}
+
+// Restriction wraps a SearchExpression in the FindItem Restriction element
+type Restriction struct {
+	Expression SearchExpression
+}
+
+func (r Restriction) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(e, start, func() error {
+		return r.Expression.encodeExpression(e)
+	})
+}
+
+// comparison compares a property with a constant, e.g. IsEqualTo or IsGreaterThan
+type comparison struct {
+	op    string
+	path  PropertyPath
+	value string
+}
+
+func (c comparison) encodeExpression(e *xml.Encoder) error {
+	return encodeWrapped(e, tElement(c.op), func() error {
+		if err := c.path.encodePath(e); err != nil {
+			return err
+		}
+		return encodeWrapped(e, tElement("FieldURIOrConstant"), func() error {
+			return encodeConstant(e, c.value)
+		})
+	})
+}
+
+// IsEqualTo matches items whose property equals value. Values are formatted as EWS expects:
+// times in UTC, booleans as true/false and everything else with fmt.Sprint.
+func IsEqualTo(path PropertyPath, value interface{}) SearchExpression {
+	return comparison{op: "IsEqualTo", path: path, value: constantValue(value)}
+}
+
+// IsNotEqualTo matches items whose property differs from value
+func IsNotEqualTo(path PropertyPath, value interface{}) SearchExpression {
+	return comparison{op: "IsNotEqualTo", path: path, value: constantValue(value)}
+}
+
+// IsGreaterThan matches items whose property is greater than value, e.g. starts after a time
+func IsGreaterThan(path PropertyPath, value interface{}) SearchExpression {
+	return comparison{op: "IsGreaterThan", path: path, value: constantValue(value)}
+}
+
+// IsGreaterThanOrEqualTo matches items whose property is greater than or equal to value
+func IsGreaterThanOrEqualTo(path PropertyPath, value interface{}) SearchExpression {
+	return comparison{op: "IsGreaterThanOrEqualTo", path: path, value: constantValue(value)}
+}
+
+// IsLessThan matches items whose property is less than value
+func IsLessThan(path PropertyPath, value interface{}) SearchExpression {
+	return comparison{op: "IsLessThan", path: path, value: constantValue(value)}
+}
+
+// IsLessThanOrEqualTo matches items whose property is less than or equal to value
+func IsLessThanOrEqualTo(path PropertyPath, value interface{}) SearchExpression {
+	return comparison{op: "IsLessThanOrEqualTo", path: path, value: constantValue(value)}
+}
+
+// contains matches a substring or prefix of a string property
+type contains struct {
+	path       PropertyPath
+	value      string
+	mode       string
+	comparison string
+}
+
+func (c contains) encodeExpression(e *xml.Encoder) error {
+	start := tElement("Contains")
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "ContainmentMode"}, Value: c.mode},
+		{Name: xml.Name{Local: "ContainmentComparison"}, Value: c.comparison},
+	}
+	return encodeWrapped(e, start, func() error {
+		if err := c.path.encodePath(e); err != nil {
+			return err
+		}
+		return encodeConstant(e, c.value)
+	})
+}
+
+// Contains matches items whose string property contains value, ignoring case
+func Contains(path PropertyPath, value string) SearchExpression {
+	return contains{path: path, value: value, mode: "Substring", comparison: "IgnoreCase"}
+}
+
+// StartsWith matches items whose string property starts with value, ignoring case
+func StartsWith(path PropertyPath, value string) SearchExpression {
+	return contains{path: path, value: value, mode: "Prefixed", comparison: "IgnoreCase"}
+}
+
+// exists matches items that have a value for a property
+type exists struct {
+	path PropertyPath
+}
+
+func (x exists) encodeExpression(e *xml.Encoder) error {
+	return encodeWrapped(e, tElement("Exists"), func() error {
+		return x.path.encodePath(e)
+	})
+}
+
+// Exists matches items that have a value for the property
+func Exists(path PropertyPath) SearchExpression {
+	return exists{path: path}
+}
+
+// logical combines expressions with And or Or
+type logical struct {
+	op    string
+	exprs []SearchExpression
+}
+
+func (l logical) encodeExpression(e *xml.Encoder) error {
+	switch len(l.exprs) {
+	case 0:
+		return fmt.Errorf("%s restriction needs at least one expression", l.op)
+	case 1:
+		return l.exprs[0].encodeExpression(e)
+	}
+
+	return encodeWrapped(e, tElement(l.op), func() error {
+		for _, expr := range l.exprs {
+			if err := expr.encodeExpression(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// And matches items that match every expression
+func And(exprs ...SearchExpression) SearchExpression {
+	return logical{op: "And", exprs: exprs}
+}
+
+// Or matches items that match at least one expression
+func Or(exprs ...SearchExpression) SearchExpression {
+	return logical{op: "Or", exprs: exprs}
+}
+
+// not negates an expression
+type not struct {
+	expr SearchExpression
+}
+
+func (n not) encodeExpression(e *xml.Encoder) error {
+	return encodeWrapped(e, tElement("Not"), func() error {
+		return n.expr.encodeExpression(e)
+	})
+}
+
+// Not matches items that do not match expr
+func Not(expr SearchExpression) SearchExpression {
+	return not{expr: expr}
+}
+
+// FieldOrder is a single property of a sort order
+type FieldOrder struct {
+	Path       PropertyPath
+	Descending bool
+}
+
+// Ascending sorts by path from lowest to highest
+func Ascending(path PropertyPath) FieldOrder {
+	return FieldOrder{Path: path}
+}
+
+// Descending sorts by path from highest to lowest
+func Descending(path PropertyPath) FieldOrder {
+	return FieldOrder{Path: path, Descending: true}
+}
+
+// SortOrder lists the properties items are sorted by, most significant first
+type SortOrder []FieldOrder
+
+func (s SortOrder) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(e, start, func() error {
+		for _, order := range s {
+			direction := "Ascending"
+			if order.Descending {
+				direction = "Descending"
+			}
+			fieldOrder := tElement("FieldOrder")
+			fieldOrder.Attr = []xml.Attr{{Name: xml.Name{Local: "Order"}, Value: direction}}
+			if err := encodeWrapped(e, fieldOrder, func() error {
+				return order.Path.encodePath(e)
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// tElement returns the start of an element in the types namespace
+func tElement(name string) xml.StartElement {
+	return xml.StartElement{Name: xml.Name{Local: "t:" + name}}
+}
+
+// encodeWrapped writes start, the tokens produced by inner and the matching end element
+func encodeWrapped(e *xml.Encoder, start xml.StartElement, inner func() error) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := inner(); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+func encodeConstant(e *xml.Encoder, value string) error {
+	constant := tElement("Constant")
+	constant.Attr = []xml.Attr{{Name: xml.Name{Local: "Value"}, Value: value}}
+	return encodeWrapped(e, constant, func() error { return nil })
+}
+
+// constantValue formats a restriction value the way EWS expects it
+func constantValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format("2006-01-02T15:04:05Z")
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
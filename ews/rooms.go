@@ -0,0 +1,198 @@
+package ews
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// RoomType distinguishes meeting rooms from other bookable resources
+type RoomType string
+
+// RoomType constants
+const (
+	RoomTypeRoom      RoomType = "Room"
+	RoomTypeEquipment RoomType = "Equipment"
+)
+
+// RoomList is a distribution list grouping meeting rooms, e.g. per building
+type RoomList struct {
+	Name  string
+	Email string
+}
+
+// Room is a bookable resource mailbox. EWS only returns its name and address; the other fields
+// are filled in from the directory when known, e.g. by the impersonation client from WorkMail.
+type Room struct {
+	Name        string
+	Email       string
+	Type        RoomType // Empty when unknown
+	Capacity    int      // Number of seats; zero when unknown
+	Description string
+	AutoAccept  bool // Whether the resource accepts meeting requests automatically
+}
+
+type GetRoomListsRequest struct {
+	XMLNSm string `xml:"xmlns:m,attr"`
+}
+
+type GetRoomsRequest struct {
+	XMLNSm   string          `xml:"xmlns:m,attr"`
+	RoomList RoomListAddress `xml:"m:RoomList"`
+}
+
+type RoomListAddress struct {
+	EmailAddress string `xml:"t:EmailAddress"`
+}
+
+// GetRoomLists response structures
+type GetRoomListsResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetRoomListsResponse struct {
+			ResponseClass string        `xml:"ResponseClass,attr"`
+			ResponseCode  string        `xml:"ResponseCode"`
+			MessageText   string        `xml:"MessageText"`
+			RoomLists     []RoomAddress `xml:"RoomLists>Address"`
+		} `xml:"GetRoomListsResponse"`
+	} `xml:"Body"`
+}
+
+// GetRooms response structures
+type GetRoomsResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetRoomsResponse struct {
+			ResponseClass string        `xml:"ResponseClass,attr"`
+			ResponseCode  string        `xml:"ResponseCode"`
+			MessageText   string        `xml:"MessageText"`
+			Rooms         []RoomAddress `xml:"Rooms>Room>Id"`
+		} `xml:"GetRoomsResponse"`
+	} `xml:"Body"`
+}
+
+// RoomAddress is a room or room list mailbox returned by the server
+type RoomAddress struct {
+	Name         string `xml:"Name"`
+	EmailAddress string `xml:"EmailAddress"`
+	MailboxType  string `xml:"MailboxType"`
+}
+
+// NewGetRoomsRequest returns the request listing the rooms of a room list
+func NewGetRoomsRequest(roomListEmail string) *GetRoomsRequest {
+	return &GetRoomsRequest{
+		XMLNSm:   "http://schemas.microsoft.com/exchange/services/2006/messages",
+		RoomList: RoomListAddress{EmailAddress: roomListEmail},
+	}
+}
+
+// GetRoomLists returns the room lists of the organization
+func (c *EWSClient) GetRoomLists(ctx context.Context) ([]RoomList, error) {
+	request := &GetRoomListsRequest{XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages"}
+
+	var responseEnvelope GetRoomListsResponseEnvelope
+	if err := c.doRequest(ctx, Body{GetRoomLists: request}, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	response := responseEnvelope.Body.GetRoomListsResponse
+	if response.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error: %s", response.ResponseCode)
+	}
+
+	return RoomListsOf(response.RoomLists), nil
+}
+
+// GetRooms returns the rooms of the room list with the given address
+func (c *EWSClient) GetRooms(ctx context.Context, roomListEmail string) ([]Room, error) {
+	var responseEnvelope GetRoomsResponseEnvelope
+	if err := c.doRequest(ctx, Body{GetRooms: NewGetRoomsRequest(roomListEmail)}, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	response := responseEnvelope.Body.GetRoomsResponse
+	if response.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error: %s", response.ResponseCode)
+	}
+
+	return RoomsOf(response.Rooms), nil
+}
+
+// RoomListsOf converts the addresses returned by GetRoomLists
+func RoomListsOf(addresses []RoomAddress) []RoomList {
+	lists := make([]RoomList, 0, len(addresses))
+	for _, address := range addresses {
+		lists = append(lists, RoomList{Name: address.Name, Email: address.EmailAddress})
+	}
+	return lists
+}
+
+// RoomsOf converts the addresses returned by GetRooms
+func RoomsOf(addresses []RoomAddress) []Room {
+	rooms := make([]Room, 0, len(addresses))
+	for _, address := range addresses {
+		rooms = append(rooms, Room{Name: address.Name, Email: address.EmailAddress})
+	}
+	return rooms
+}
+
+// roomCapacityPattern matches capacities written like "Capacity: 8", "seats 8" or "8 seats"
+var roomCapacityPattern = regexp.MustCompile(`(?i)(?:capacity|seats)\s*[:=]?\s*(\d+)|(\d+)\s*(?:seats|people|persons)\b`)
+
+// ParseRoomCapacity reads the number of seats from a room description such as "Capacity: 8" or
+// "Boardroom, 12 seats, projector". It returns zero when the description has no capacity.
+func ParseRoomCapacity(description string) int {
+	match := roomCapacityPattern.FindStringSubmatch(description)
+	if match == nil {
+		return 0
+	}
+	capacity, _ := strconv.Atoi(match[1] + match[2])
+	return capacity
+}
+
+// FindFreeRooms returns the rooms with at least minCapacity seats that are free during the slot,
+// smallest first, reading their busy time from source and applying opts as CheckSlot does. Rooms of
+// unknown capacity are only returned when minCapacity is zero; equipment is never returned.
+func FindFreeRooms(ctx context.Context, source BusySource, rooms []Room, slot TimeSlot, minCapacity int, opts AvailabilityOptions) ([]Room, error) {
+	var candidates []Room
+	var emails []string
+	for _, room := range rooms {
+		if room.Type == RoomTypeEquipment || room.Capacity < minCapacity {
+			continue
+		}
+		candidates = append(candidates, room)
+		emails = append(emails, room.Email)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	from, to := opts.SearchRange(slot.Start, slot.End)
+	busyTimes, err := source.BusyTimes(ctx, emails, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(busyTimes) != len(candidates) {
+		return nil, fmt.Errorf("expected busy time of %d rooms, got %d", len(candidates), len(busyTimes))
+	}
+
+	var free []Room
+	for i, room := range candidates {
+		busy := busyTimes[i]
+		if busy.Err != nil {
+			return nil, fmt.Errorf("error reading busy time of %s: %w", room.Email, busy.Err)
+		}
+		if available, _ := opts.CheckSlot(slot, busy.Busy); available {
+			free = append(free, room)
+		}
+	}
+
+	slices.SortStableFunc(free, func(a, b Room) int {
+		return cmp.Compare(a.Capacity, b.Capacity)
+	})
+	return free, nil
+}
@@ -0,0 +1,59 @@
+package ews
+
+import "encoding/xml"
+
+// BaseShape selects the set of properties returned for each item
+type BaseShape string
+
+// BaseShape constants
+const (
+	BaseShapeIdOnly        BaseShape = "IdOnly"
+	BaseShapeDefault       BaseShape = "Default"
+	BaseShapeAllProperties BaseShape = "AllProperties"
+)
+
+// Shape selects the properties returned for each item. Properties that are not requested are
+// left at their zero value in the decoded items.
+type Shape struct {
+	BaseShape            BaseShape      // Defaults to BaseShapeAllProperties
+	AdditionalProperties []PropertyPath // Properties returned in addition to BaseShape, e.g. FieldStart
+}
+
+func (s Shape) itemShape() ItemShape {
+	shape := ItemShape{
+		BaseShape:            s.BaseShape,
+		AdditionalProperties: s.AdditionalProperties,
+	}
+	if shape.BaseShape == "" {
+		shape.BaseShape = BaseShapeAllProperties
+	}
+	return shape
+}
+
+// AdditionalProperties lists the FieldURI and ExtendedFieldURI paths of an item shape
+type AdditionalProperties []PropertyPath
+
+func (a AdditionalProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(e, start, func() error {
+		for _, path := range a {
+			if err := path.encodePath(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ExtendedFieldURI identifies a MAPI property, either by tag or by a property set and a name or ID
+type ExtendedFieldURI struct {
+	DistinguishedPropertySetId string `xml:"DistinguishedPropertySetId,attr,omitempty"`
+	PropertySetId              string `xml:"PropertySetId,attr,omitempty"`
+	PropertyTag                string `xml:"PropertyTag,attr,omitempty"`
+	PropertyName               string `xml:"PropertyName,attr,omitempty"`
+	PropertyId                 string `xml:"PropertyId,attr,omitempty"`
+	PropertyType               string `xml:"PropertyType,attr"`
+}
+
+func (f ExtendedFieldURI) encodePath(e *xml.Encoder) error {
+	return e.EncodeElement(f, xml.StartElement{Name: xml.Name{Local: "t:ExtendedFieldURI"}})
+}
@@ -0,0 +1,207 @@
+package ews
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SuggestionQuality rates a meeting time suggested by the server by the share of attendees who are free
+type SuggestionQuality string
+
+// SuggestionQuality constants
+const (
+	SuggestionQualityExcellent SuggestionQuality = "Excellent" // Every attendee is free
+	SuggestionQualityGood      SuggestionQuality = "Good"      // The share of busy attendees is at most the good threshold
+	SuggestionQualityFair      SuggestionQuality = "Fair"      // More attendees are busy than the good threshold allows
+	SuggestionQualityPoor      SuggestionQuality = "Poor"      // At least half of the attendees are busy
+)
+
+// SuggestionsOptions controls the meeting times suggested by GetMeetingSuggestions
+type SuggestionsOptions struct {
+	Duration              time.Duration     // Length of the meeting; defaults to 30 minutes
+	GoodThreshold         int               // Percentage of busy attendees, 1-49, up to which a time is Good; defaults to 25
+	MaxPerDay             int               // Maximum suggestions per day, up to 48; defaults to the server's 10
+	MaxNonWorkHoursPerDay int               // Maximum suggestions per day outside working hours; defaults to none
+	MinimumQuality        SuggestionQuality // Lowest quality returned; defaults to the server's Fair
+}
+
+// SuggestionDay holds the meeting times suggested for one day
+type SuggestionDay struct {
+	Date        time.Time // Midnight of the day
+	Quality     SuggestionQuality
+	Suggestions []Suggestion
+}
+
+// Suggestion is a meeting time suggested by the server
+type Suggestion struct {
+	TimeSlot   // Start is the suggested time, End adds the requested duration
+	Quality    SuggestionQuality
+	IsWorkTime bool
+	Conflicts  []AttendeeConflict // One per attendee, in the order they were given
+}
+
+// AttendeeConflict tells how an attendee is affected by a suggested meeting time
+type AttendeeConflict struct {
+	Email string
+	// Kind is "Individual" for a single mailbox, "Group" for a distribution list, and "Unknown" or
+	// "TooBigGroup" when the server has no data
+	Kind     string
+	BusyType LegacyFreeBusyStatus // Status of an individual attendee
+
+	// Members of a distribution list
+	NumberOfMembers             int
+	NumberOfMembersAvailable    int
+	NumberOfMembersWithConflict int
+	NumberOfMembersWithNoData   int
+}
+
+type SuggestionsViewOptions struct {
+	GoodThreshold                  int               `xml:"t:GoodThreshold,omitempty"`
+	MaximumResultsByDay            int               `xml:"t:MaximumResultsByDay,omitempty"`
+	MaximumNonWorkHourResultsByDay int               `xml:"t:MaximumNonWorkHourResultsByDay,omitempty"`
+	MeetingDurationInMinutes       int               `xml:"t:MeetingDurationInMinutes"`
+	MinimumSuggestionQuality       SuggestionQuality `xml:"t:MinimumSuggestionQuality,omitempty"`
+	DetailedSuggestionsWindow      TimeWindow        `xml:"t:DetailedSuggestionsWindow"`
+}
+
+// SuggestionsResponse is the suggestions part of a GetUserAvailability response
+type SuggestionsResponse struct {
+	ResponseMessage struct {
+		ResponseClass string `xml:"ResponseClass,attr"`
+		ResponseCode  string `xml:"ResponseCode"`
+		MessageText   string `xml:"MessageText"`
+	} `xml:"ResponseMessage"`
+	SuggestionDayResults []struct {
+		Date        string            `xml:"Date"`
+		DayQuality  SuggestionQuality `xml:"DayQuality"`
+		Suggestions []struct {
+			MeetingTime       string            `xml:"MeetingTime"`
+			IsWorkTime        bool              `xml:"IsWorkTime"`
+			SuggestionQuality SuggestionQuality `xml:"SuggestionQuality"`
+			ConflictData      struct {
+				Attendees []struct {
+					XMLName                     xml.Name
+					BusyType                    LegacyFreeBusyStatus `xml:"BusyType"`
+					NumberOfMembers             int                  `xml:"NumberOfMembers"`
+					NumberOfMembersAvailable    int                  `xml:"NumberOfMembersAvailable"`
+					NumberOfMembersWithConflict int                  `xml:"NumberOfMembersWithConflict"`
+					NumberOfMembersWithNoData   int                  `xml:"NumberOfMembersWithNoData"`
+				} `xml:",any"`
+			} `xml:"AttendeeConflictDataArray"`
+		} `xml:"SuggestionArray>Suggestion"`
+	} `xml:"SuggestionDayResultArray>SuggestionDayResult"`
+}
+
+// NewSuggestionsRequest builds the GetUserAvailability request for meeting times suggested between
+// start and end. Times are sent and returned in the location of start.
+func NewSuggestionsRequest(attendees []MeetingAttendee, start, end time.Time, opts SuggestionsOptions) *GetUserAvailabilityRequest {
+	request := &GetUserAvailabilityRequest{
+		XMLNSm:   "http://schemas.microsoft.com/exchange/services/2006/messages",
+		TimeZone: NewSerializableTimeZone(start),
+		SuggestionsViewOptions: &SuggestionsViewOptions{
+			GoodThreshold:                  opts.GoodThreshold,
+			MaximumResultsByDay:            opts.MaxPerDay,
+			MaximumNonWorkHourResultsByDay: opts.MaxNonWorkHoursPerDay,
+			MeetingDurationInMinutes:       int(opts.duration() / time.Minute),
+			MinimumSuggestionQuality:       opts.MinimumQuality,
+			DetailedSuggestionsWindow: TimeWindow{
+				StartTime: start.Format("2006-01-02T15:04:05"),
+				EndTime:   end.In(start.Location()).Format("2006-01-02T15:04:05"),
+			},
+		},
+	}
+	for _, attendee := range attendees {
+		attendeeType := "Required"
+		switch {
+		case attendee.Room:
+			attendeeType = "Room"
+		case attendee.Optional:
+			attendeeType = "Optional"
+		}
+		request.MailboxDataArray.MailboxData = append(request.MailboxDataArray.MailboxData, MailboxData{
+			Email:        MailboxAddress{Address: attendee.Email},
+			AttendeeType: attendeeType,
+		})
+	}
+	return request
+}
+
+func (o SuggestionsOptions) duration() time.Duration {
+	if o.Duration <= 0 {
+		return 30 * time.Minute
+	}
+	return o.Duration
+}
+
+// Days converts the suggestions for the attendees of a request built by NewSuggestionsRequest,
+// parsing times in loc
+func (r SuggestionsResponse) Days(attendees []MeetingAttendee, loc *time.Location, opts SuggestionsOptions) ([]SuggestionDay, error) {
+	if message := r.ResponseMessage; message.ResponseClass != "Success" {
+		return nil, &ResponseError{ResponseClass: message.ResponseClass, ResponseCode: message.ResponseCode, MessageText: message.MessageText}
+	}
+
+	days := make([]SuggestionDay, 0, len(r.SuggestionDayResults))
+	for _, result := range r.SuggestionDayResults {
+		date, err := parseAvailabilityTime(result.Date, loc)
+		if err != nil {
+			return nil, err
+		}
+		day := SuggestionDay{Date: date, Quality: result.DayQuality}
+
+		for _, s := range result.Suggestions {
+			start, err := parseAvailabilityTime(s.MeetingTime, loc)
+			if err != nil {
+				return nil, err
+			}
+			suggestion := Suggestion{
+				TimeSlot:   TimeSlot{Start: start, End: start.Add(opts.duration())},
+				Quality:    s.SuggestionQuality,
+				IsWorkTime: s.IsWorkTime,
+			}
+			for i, data := range s.ConflictData.Attendees {
+				conflict := AttendeeConflict{
+					Kind:                        strings.TrimSuffix(data.XMLName.Local, "AttendeeConflictData"),
+					BusyType:                    data.BusyType,
+					NumberOfMembers:             data.NumberOfMembers,
+					NumberOfMembersAvailable:    data.NumberOfMembersAvailable,
+					NumberOfMembersWithConflict: data.NumberOfMembersWithConflict,
+					NumberOfMembersWithNoData:   data.NumberOfMembersWithNoData,
+				}
+				if i < len(attendees) {
+					conflict.Email = attendees[i].Email
+				}
+				suggestion.Conflicts = append(suggestion.Conflicts, conflict)
+			}
+			day.Suggestions = append(day.Suggestions, suggestion)
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// GetMeetingSuggestions asks the server for meeting times between start and end at which the attendees
+// can meet, rated by how many of them are free and whether the time is within working hours. The
+// attendees are sent in one request, so at most 100 are accepted, and the range is limited to 42 days.
+func (c *EWSClient) GetMeetingSuggestions(ctx context.Context, attendees []MeetingAttendee, start, end time.Time, opts SuggestionsOptions) ([]SuggestionDay, error) {
+	if len(attendees) > maxAvailabilityMailboxes {
+		return nil, fmt.Errorf("at most %d attendees can be sent for suggestions, got %d", maxAvailabilityMailboxes, len(attendees))
+	}
+	start = start.In(c.TimeZone)
+
+	var responseEnvelope GetUserAvailabilityResponseEnvelope
+	request := NewSuggestionsRequest(attendees, start, end, opts)
+	if err := c.doRequest(ctx, Body{GetUserAvailability: request}, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	response := responseEnvelope.Body.GetUserAvailabilityResponse.SuggestionsResponse
+	if response == nil {
+		return nil, fmt.Errorf("EWS error: no suggestions in response")
+	}
+	return response.Days(attendees, c.TimeZone, opts)
+}
@@ -0,0 +1,460 @@
+package ews
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeZoneDefinition describes a time zone in the Exchange 2010 format used by
+// the StartTimeZone and EndTimeZone elements of calendar items
+type TimeZoneDefinition struct {
+	Id                string               `xml:"Id,attr,omitempty"`
+	Name              string               `xml:"Name,attr,omitempty"`
+	Periods           *TimeZonePeriods     `xml:"t:Periods,omitempty"`
+	TransitionsGroups *TransitionsGroups   `xml:"t:TransitionsGroups,omitempty"`
+	Transitions       *TimeZoneTransitions `xml:"t:Transitions,omitempty"`
+}
+
+type TimeZonePeriods struct {
+	Period []TimeZonePeriod `xml:"t:Period"`
+}
+
+// TimeZonePeriod is a named UTC bias, such as the standard or daylight time of a zone
+type TimeZonePeriod struct {
+	Bias string `xml:"Bias,attr"`
+	Name string `xml:"Name,attr"`
+	Id   string `xml:"Id,attr"`
+}
+
+type TransitionsGroups struct {
+	TransitionsGroup []TransitionsGroup `xml:"t:TransitionsGroup"`
+}
+
+// TransitionsGroup holds either a single transition to a period or a set of yearly recurring transitions
+type TransitionsGroup struct {
+	Id                     string                   `xml:"Id,attr"`
+	Transition             []TimeZoneTransition     `xml:"t:Transition,omitempty"`
+	RecurringDayTransition []RecurringDayTransition `xml:"t:RecurringDayTransition,omitempty"`
+}
+
+type TimeZoneTransitions struct {
+	Transition []TimeZoneTransition `xml:"t:Transition"`
+}
+
+type TimeZoneTransition struct {
+	To TransitionTarget `xml:"t:To"`
+}
+
+// TransitionTarget points a transition at a period or a transitions group
+type TransitionTarget struct {
+	Kind  string `xml:"Kind,attr"`
+	Value string `xml:",chardata"`
+}
+
+// RecurringDayTransition is a transition that happens every year, e.g. on the last Sunday of March
+type RecurringDayTransition struct {
+	To         TransitionTarget `xml:"t:To"`
+	TimeOffset string           `xml:"t:TimeOffset"`
+	Month      int              `xml:"t:Month"`
+	DayOfWeek  string           `xml:"t:DayOfWeek"`
+	Occurrence int              `xml:"t:Occurrence"` // 1-4, or -1 for the last occurrence in the month
+}
+
+// MeetingTimeZone describes a time zone in the Exchange 2007 format
+type MeetingTimeZone struct {
+	TimeZoneName string      `xml:"TimeZoneName,attr,omitempty"`
+	BaseOffset   string      `xml:"t:BaseOffset"`
+	Standard     *TimeChange `xml:"t:Standard,omitempty"`
+	Daylight     *TimeChange `xml:"t:Daylight,omitempty"`
+}
+
+// TimeChange describes the yearly switch to standard or daylight time of a MeetingTimeZone
+type TimeChange struct {
+	TimeZoneName             string                   `xml:"TimeZoneName,attr,omitempty"`
+	Offset                   string                   `xml:"t:Offset"`
+	RelativeYearlyRecurrence RelativeYearlyRecurrence `xml:"t:RelativeYearlyRecurrence"`
+	Time                     string                   `xml:"t:Time"`
+}
+
+type RelativeYearlyRecurrence struct {
+	DaysOfWeek     string `xml:"t:DaysOfWeek"`
+	DayOfWeekIndex string `xml:"t:DayOfWeekIndex"`
+	Month          string `xml:"t:Month"`
+}
+
+// TimeZoneInfo identifies the time zone of a retrieved calendar item.
+// Id and Name are set for StartTimeZone/EndTimeZone, TimeZoneName for MeetingTimeZone.
+type TimeZoneInfo struct {
+	Id           string `xml:"Id,attr"`
+	Name         string `xml:"Name,attr"`
+	TimeZoneName string `xml:"TimeZoneName,attr"`
+}
+
+// Location resolves the zone, identified by a Windows or IANA ID, into a *time.Location
+func (z TimeZoneInfo) Location() (*time.Location, error) {
+	return resolveZone(z.Id, z.TimeZoneName)
+}
+
+// resolveZone loads the first of ids that is a known Windows or IANA zone
+func resolveZone(ids ...string) (*time.Location, error) {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if loc, err := LoadWindowsLocation(id); err == nil {
+			return loc, nil
+		}
+		if loc, err := time.LoadLocation(id); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown time zone %q", strings.Join(ids, ", "))
+}
+
+// zoneTransition is a yearly switch between standard and daylight time, expressed
+// in the local wall clock time in effect before the switch
+type zoneTransition struct {
+	month      time.Month
+	weekday    time.Weekday
+	occurrence int // 1-4, or 5 for the last occurrence in the month
+	timeOfDay  time.Duration
+}
+
+// zoneRules is the standard/daylight layout of a location in a given year
+type zoneRules struct {
+	name           string        // IANA name
+	id             string        // Windows ID, or empty when the zone has none
+	standardOffset time.Duration // east of UTC
+	daylightOffset time.Duration
+	hasDaylight    bool
+	toDaylight     zoneTransition
+	toStandard     zoneTransition
+}
+
+// zoneRulesFor derives the yearly rules of the location that t is in, for the year of t
+func zoneRulesFor(t time.Time) zoneRules {
+	loc := t.Location()
+	_, offset := t.Zone()
+	rules := zoneRules{
+		name:           ianaName(loc),
+		standardOffset: time.Duration(offset) * time.Second,
+	}
+	id, known := WindowsZoneID(rules.name)
+	rules.id = id
+
+	yearStart := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	var toDaylight, toStandard *zoneTransition
+	current := yearStart
+	// A handful of iterations covers every real-world zone; the bound guards against odd data
+	for i := 0; i < 8; i++ {
+		_, next := current.ZoneBounds()
+		if next.IsZero() || !next.Before(yearEnd) {
+			break
+		}
+
+		_, before := current.Zone()
+		_, after := next.Zone()
+		transition := transitionAt(next, time.Duration(before)*time.Second)
+		if next.IsDST() && toDaylight == nil {
+			toDaylight = &transition
+			rules.daylightOffset = time.Duration(after) * time.Second
+			rules.standardOffset = time.Duration(before) * time.Second
+		} else if !next.IsDST() && toStandard == nil {
+			toStandard = &transition
+			rules.standardOffset = time.Duration(after) * time.Second
+		}
+		current = next
+	}
+
+	if toDaylight != nil && toStandard != nil {
+		rules.hasDaylight = true
+		rules.toDaylight = *toDaylight
+		rules.toStandard = *toStandard
+	} else if t.IsDST() {
+		// A zone that only changed its base offset this year is treated as having no daylight time
+		rules.standardOffset = time.Duration(offset) * time.Second
+	}
+
+	if !known {
+		// A zone missing from the mapping table, time.Local without zone data or a fixed zone: the
+		// server only knows Windows IDs, so use a known zone with the same rules
+		rules.name, rules.id = matchingZone(rules, t.Year())
+	}
+
+	return rules
+}
+
+var (
+	matchingZonesMu sync.Mutex
+	matchingZones   = make(map[zoneRules][2]string)
+)
+
+// matchingZone returns the IANA name and Windows ID of the first zone of the Windows mapping table
+// whose rules in the given year are those of rules, or empty strings if there is none
+func matchingZone(rules zoneRules, year int) (string, string) {
+	rules.name, rules.id = "", ""
+
+	matchingZonesMu.Lock()
+	defer matchingZonesMu.Unlock()
+	if match, ok := matchingZones[rules]; ok {
+		return match[0], match[1]
+	}
+
+	loadWindowsZones()
+	ids := make([]string, 0, len(ianaByWindows))
+	for id := range ianaByWindows {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var match [2]string
+	for _, id := range ids {
+		candidate, err := time.LoadLocation(ianaByWindows[id])
+		if err != nil {
+			continue
+		}
+		candidateRules := zoneRulesFor(time.Date(year, time.January, 1, 0, 0, 0, 0, candidate))
+		name := candidateRules.name
+		candidateRules.name, candidateRules.id = "", ""
+		if candidateRules == rules {
+			match = [2]string{name, id}
+			break
+		}
+	}
+	matchingZones[rules] = match
+	return match[0], match[1]
+}
+
+// ErrUnknownTimeZone is returned when the time zone of an event cannot be identified to the server,
+// e.g. a zone missing from the Windows mapping table or time.Local in a container whose TZ and
+// /etc/localtime do not name the zone, when no zone of the table has the same rules
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// CheckTimeZones returns ErrUnknownTimeZone if the location of one of times cannot be identified to
+// the server. Events are created and updated with the time zones of their start and end.
+func CheckTimeZones(times ...time.Time) error {
+	for _, t := range times {
+		if zoneRulesFor(t).id == "" {
+			return fmt.Errorf("%w: time zone %q of %s matches no Windows time zone; set TZ or use a location loaded by name",
+				ErrUnknownTimeZone, t.Location(), t.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// transitionAt describes the transition happening at instant in terms of the wall clock before it
+func transitionAt(instant time.Time, before time.Duration) zoneTransition {
+	wall := instant.UTC().Add(before)
+	daysInMonth := time.Date(wall.Year(), wall.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	occurrence := (wall.Day()-1)/7 + 1
+	if wall.Day()+7 > daysInMonth {
+		occurrence = 5
+	}
+
+	return zoneTransition{
+		month:      wall.Month(),
+		weekday:    wall.Weekday(),
+		occurrence: occurrence,
+		timeOfDay:  time.Duration(wall.Hour())*time.Hour + time.Duration(wall.Minute())*time.Minute + time.Duration(wall.Second())*time.Second,
+	}
+}
+
+// NewTimeZoneDefinition builds the Exchange 2010 time zone definition of the location t is in,
+// using the daylight saving rules that apply in the year of t. The definition is identified by the
+// Windows ID of the location, or of a zone of the mapping table with the same rules, which lets the
+// server apply its own rules. Its Id is empty if there is none; CheckTimeZones reports that case.
+func NewTimeZoneDefinition(t time.Time) TimeZoneDefinition {
+	rules := zoneRulesFor(t)
+	standardID := "trule:" + rules.id + "/Standard"
+	daylightID := "trule:" + rules.id + "/Daylight"
+
+	def := TimeZoneDefinition{
+		Id:   rules.id,
+		Name: rules.name,
+		Periods: &TimeZonePeriods{
+			Period: []TimeZonePeriod{
+				{Bias: xsDuration(-rules.standardOffset), Name: "Standard", Id: standardID},
+			},
+		},
+		TransitionsGroups: &TransitionsGroups{
+			TransitionsGroup: []TransitionsGroup{{Id: "0"}},
+		},
+		Transitions: &TimeZoneTransitions{
+			Transition: []TimeZoneTransition{
+				{To: TransitionTarget{Kind: "Group", Value: "0"}},
+			},
+		},
+	}
+
+	group := &def.TransitionsGroups.TransitionsGroup[0]
+	if !rules.hasDaylight {
+		group.Transition = []TimeZoneTransition{
+			{To: TransitionTarget{Kind: "Period", Value: standardID}},
+		}
+		return def
+	}
+
+	def.Periods.Period = append(def.Periods.Period, TimeZonePeriod{
+		Bias: xsDuration(-rules.daylightOffset), Name: "Daylight", Id: daylightID,
+	})
+	group.RecurringDayTransition = []RecurringDayTransition{
+		recurringDayTransition(rules.toDaylight, daylightID),
+		recurringDayTransition(rules.toStandard, standardID),
+	}
+
+	return def
+}
+
+func recurringDayTransition(tr zoneTransition, periodID string) RecurringDayTransition {
+	occurrence := tr.occurrence
+	if occurrence == 5 {
+		// The schema counts the last occurrence in the month as -1
+		occurrence = -1
+	}
+	return RecurringDayTransition{
+		To:         TransitionTarget{Kind: "Period", Value: periodID},
+		TimeOffset: xsDuration(tr.timeOfDay),
+		Month:      int(tr.month),
+		DayOfWeek:  tr.weekday.String(),
+		Occurrence: occurrence,
+	}
+}
+
+// NewMeetingTimeZone builds the Exchange 2007 time zone description of the location t is in,
+// using the daylight saving rules that apply in the year of t
+func NewMeetingTimeZone(t time.Time) MeetingTimeZone {
+	rules := zoneRulesFor(t)
+	mtz := MeetingTimeZone{
+		TimeZoneName: rules.id,
+		BaseOffset:   xsDuration(-rules.standardOffset),
+	}
+
+	if rules.hasDaylight {
+		mtz.Standard = timeChange(rules.toStandard, 0, "Standard")
+		mtz.Daylight = timeChange(rules.toDaylight, -(rules.daylightOffset - rules.standardOffset), "Daylight")
+	}
+
+	return mtz
+}
+
+var dayOfWeekIndexNames = [...]string{"", "First", "Second", "Third", "Fourth", "Last"}
+
+func timeChange(tr zoneTransition, offset time.Duration, name string) *TimeChange {
+	tod := tr.timeOfDay
+	return &TimeChange{
+		TimeZoneName: name,
+		Offset:       xsDuration(offset),
+		RelativeYearlyRecurrence: RelativeYearlyRecurrence{
+			DaysOfWeek:     tr.weekday.String(),
+			DayOfWeekIndex: dayOfWeekIndexNames[tr.occurrence],
+			Month:          tr.month.String(),
+		},
+		Time: fmt.Sprintf("%02d:%02d:%02d", int(tod.Hours()), int(tod.Minutes())%60, int(tod.Seconds())%60),
+	}
+}
+
+// xsDuration formats d as an xs:duration such as "PT10H", "-PT5H30M" or "PT0M"
+func xsDuration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteString("PT")
+
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+	if hours > 0 {
+		b.WriteString(strconv.Itoa(hours) + "H")
+	}
+	if minutes > 0 || (hours == 0 && seconds == 0) {
+		b.WriteString(strconv.Itoa(minutes) + "M")
+	}
+	if seconds > 0 {
+		b.WriteString(strconv.Itoa(seconds) + "S")
+	}
+
+	return b.String()
+}
+
+// isExchange2007 reports whether the server version predates StartTimeZone/EndTimeZone support
+func isExchange2007(version string) bool {
+	return strings.HasPrefix(version, "Exchange2007")
+}
+
+// ServerTimeZone is a time zone definition returned by GetServerTimeZones
+type ServerTimeZone struct {
+	Id      string           `xml:"Id,attr"`
+	Name    string           `xml:"Name,attr"`
+	Periods []TimeZonePeriod `xml:"Periods>Period"`
+}
+
+// Location resolves the server time zone into a *time.Location using the Windows mapping table
+func (z ServerTimeZone) Location() (*time.Location, error) {
+	return resolveZone(z.Id)
+}
+
+type GetServerTimeZonesRequest struct {
+	XMLNSm                 string       `xml:"xmlns:m,attr"`
+	ReturnFullTimeZoneData bool         `xml:"ReturnFullTimeZoneData,attr"`
+	Ids                    *TimeZoneIds `xml:"m:Ids,omitempty"`
+}
+
+type TimeZoneIds struct {
+	Id []string `xml:"t:Id"`
+}
+
+// GetServerTimeZones response structures
+type GetServerTimeZonesResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetServerTimeZonesResponse struct {
+			ResponseMessages struct {
+				GetServerTimeZonesResponseMessage GetServerTimeZonesResponseMessageType `xml:"GetServerTimeZonesResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"GetServerTimeZonesResponse"`
+	} `xml:"Body"`
+}
+
+type GetServerTimeZonesResponseMessageType struct {
+	ResponseClass       string           `xml:"ResponseClass,attr"`
+	ResponseCode        string           `xml:"ResponseCode"`
+	MessageText         string           `xml:"MessageText"`
+	TimeZoneDefinitions []ServerTimeZone `xml:"TimeZoneDefinitions>TimeZoneDefinition"`
+}
+
+// GetServerTimeZones returns the time zones known to the server. When ids is empty all
+// zones are returned; fullData also requests the periods of each zone.
+func (c *EWSClient) GetServerTimeZones(ctx context.Context, ids []string, fullData bool) ([]ServerTimeZone, error) {
+	request := &GetServerTimeZonesRequest{
+		XMLNSm:                 "http://schemas.microsoft.com/exchange/services/2006/messages",
+		ReturnFullTimeZoneData: fullData,
+	}
+	if len(ids) > 0 {
+		request.Ids = &TimeZoneIds{Id: ids}
+	}
+
+	var responseEnvelope GetServerTimeZonesResponseEnvelope
+	if err := c.doRequest(ctx, Body{GetServerTimeZones: request}, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	// Check response code
+	responseMessage := responseEnvelope.Body.GetServerTimeZonesResponse.ResponseMessages.GetServerTimeZonesResponseMessage
+	if responseMessage.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error: %s", responseMessage.ResponseCode)
+	}
+
+	return responseMessage.TimeZoneDefinitions, nil
+}
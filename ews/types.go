@@ -16,6 +16,37 @@ const (
 	NoData    LegacyFreeBusyStatus = "NoData"    // Status is unknown
 )
 
+// Sensitivity represents the sensitivity level of an item
+type Sensitivity string
+
+// Sensitivity constants
+const (
+	SensitivityNormal       Sensitivity = "Normal"       // No special handling
+	SensitivityPersonal     Sensitivity = "Personal"     // Personal item
+	SensitivityPrivate      Sensitivity = "Private"      // Details hidden from delegates and free/busy viewers
+	SensitivityConfidential Sensitivity = "Confidential" // Confidential item
+)
+
+// Importance represents the importance of an item
+type Importance string
+
+// Importance constants
+const (
+	ImportanceLow    Importance = "Low"
+	ImportanceNormal Importance = "Normal"
+	ImportanceHigh   Importance = "High"
+)
+
+// BodyType represents the format of an item body
+type BodyType string
+
+// BodyType constants
+const (
+	BodyTypeText BodyType = "Text" // Plain text body
+	BodyTypeHTML BodyType = "HTML" // HTML body
+	BodyTypeBest BodyType = "Best" // Richest format available; only valid when reading items
+)
+
 // SOAP envelope structures
 type Envelope struct {
 	XMLName xml.Name `xml:"s:Envelope"`
@@ -50,23 +81,57 @@ type FindItemResponseMessage struct {
 }
 
 type RootFolder struct {
-	Items      Items `xml:"Items"`
-	TotalItems int   `xml:"TotalItemsInView,attr"`
+	Items                   Items `xml:"Items"`
+	TotalItems              int   `xml:"TotalItemsInView,attr"`
+	IncludesLastItemInRange *bool `xml:"IncludesLastItemInRange,attr"`
+	IndexedPagingOffset     int   `xml:"IndexedPagingOffset,attr"`
 }
 
 type Items struct {
 	CalendarItem []CalendarItem `xml:"CalendarItem"`
+	Message      []Item         `xml:"Message"`
+	Item         []Item         `xml:"Item"`
+}
+
+// Item holds the common properties of a non-calendar item, such as a message
+type Item struct {
+	ItemId           ItemId      `xml:"ItemId"`
+	ItemClass        string      `xml:"ItemClass"`
+	Subject          string      `xml:"Subject"`
+	Sensitivity      Sensitivity `xml:"Sensitivity,omitempty"`
+	Importance       Importance  `xml:"Importance,omitempty"`
+	Categories       []string    `xml:"Categories>String"`
+	HasAttachments   bool        `xml:"HasAttachments"`
+	Size             int         `xml:"Size"`
+	DateTimeCreated  string      `xml:"DateTimeCreated"`
+	DateTimeReceived string      `xml:"DateTimeReceived"`
+
+	ExtendedProperties []ItemExtendedProperty `xml:"ExtendedProperty"`
 }
 
 type CalendarItem struct {
-	ItemId         ItemId `xml:"ItemId"`
-	Subject        string `xml:"Subject"`
-	Start          string `xml:"Start"`
-	End            string `xml:"End"`
-	Location       string `xml:"Location"`
-	IsAllDayEvent  bool   `xml:"IsAllDayEvent,omitempty"`
-	LegacyFreeBusy LegacyFreeBusyStatus `xml:"LegacyFreeBusyStatus,omitempty"`
-	Organizer      struct {
+	ItemId                     ItemId                 `xml:"ItemId"`
+	Subject                    string                 `xml:"Subject"`
+	Body                       ItemBody               `xml:"Body"`
+	Start                      string                 `xml:"Start"`
+	End                        string                 `xml:"End"`
+	Location                   string                 `xml:"Location"`
+	IsAllDayEvent              bool                   `xml:"IsAllDayEvent,omitempty"`
+	CalendarItemType           CalendarItemType       `xml:"CalendarItemType,omitempty"`
+	LegacyFreeBusy             LegacyFreeBusyStatus   `xml:"LegacyFreeBusyStatus,omitempty"`
+	Sensitivity                Sensitivity            `xml:"Sensitivity,omitempty"`
+	Importance                 Importance             `xml:"Importance,omitempty"`
+	Categories                 []string               `xml:"Categories>String"`
+	ReminderIsSet              bool                   `xml:"ReminderIsSet"`
+	ReminderMinutesBeforeStart int                    `xml:"ReminderMinutesBeforeStart"`
+	HasAttachments             bool                   `xml:"HasAttachments"`
+	DateTimeCreated            string                 `xml:"DateTimeCreated,omitempty"`
+	Attachments                []FileAttachment       `xml:"Attachments>FileAttachment"`
+	MeetingTimeZone            *TimeZoneInfo          `xml:"MeetingTimeZone"`
+	StartTimeZone              *TimeZoneInfo          `xml:"StartTimeZone"`
+	EndTimeZone                *TimeZoneInfo          `xml:"EndTimeZone"`
+	ExtendedProperties         []ItemExtendedProperty `xml:"ExtendedProperty"`
+	Organizer                  struct {
 		Mailbox struct {
 			Name         string `xml:"Name"`
 			EmailAddress string `xml:"EmailAddress"`
@@ -76,7 +141,8 @@ type CalendarItem struct {
 }
 
 type ItemId struct {
-	Id string `xml:"Id,attr"`
+	Id        string `xml:"Id,attr"`
+	ChangeKey string `xml:"ChangeKey,attr,omitempty"`
 }
 
 type Header struct {
@@ -92,32 +158,77 @@ type Body struct {
 	CreateItem *CreateEventRequest `xml:"m:CreateItem,omitempty"`
 	DeleteItem *DeleteItemRequest  `xml:"m:DeleteItem,omitempty"`
 	UpdateItem *UpdateItemRequest  `xml:"m:UpdateItem,omitempty"`
+	GetItem    *GetItemRequest     `xml:"m:GetItem,omitempty"`
+
+	CreateAttachment *CreateAttachmentRequest `xml:"m:CreateAttachment,omitempty"`
+	GetAttachment    *GetAttachmentRequest    `xml:"m:GetAttachment,omitempty"`
+	DeleteAttachment *DeleteAttachmentRequest `xml:"m:DeleteAttachment,omitempty"`
+
+	GetServerTimeZones *GetServerTimeZonesRequest `xml:"m:GetServerTimeZones,omitempty"`
+
+	FindFolder *FindFolderRequest `xml:"m:FindFolder,omitempty"`
+
+	MoveItem *MoveItemRequest `xml:"m:MoveItem,omitempty"`
+	CopyItem *CopyItemRequest `xml:"m:CopyItem,omitempty"`
+
+	GetUserConfiguration *GetUserConfigurationRequest `xml:"m:GetUserConfiguration,omitempty"`
+	GetUserAvailability  *GetUserAvailabilityRequest  `xml:"m:GetUserAvailabilityRequest,omitempty"`
+
+	GetRoomLists *GetRoomListsRequest `xml:"m:GetRoomLists,omitempty"`
+	GetRooms     *GetRoomsRequest     `xml:"m:GetRooms,omitempty"`
 }
 
 type FindItemRequest struct {
-	XMLName         xml.Name        `xml:"m:FindItem"`
-	XMLNSm          string          `xml:"xmlns:m,attr"`
-	Traversal       string          `xml:"Traversal,attr"`
-	ItemShape       ItemShape       `xml:"m:ItemShape"`
-	CalendarView    CalendarView    `xml:"m:CalendarView"`
-	ParentFolderIds ParentFolderIds `xml:"m:ParentFolderIds"`
+	XMLName             xml.Name             `xml:"m:FindItem"`
+	XMLNSm              string               `xml:"xmlns:m,attr"`
+	Traversal           string               `xml:"Traversal,attr"`
+	ItemShape           ItemShape            `xml:"m:ItemShape"`
+	IndexedPageItemView *IndexedPageItemView `xml:"m:IndexedPageItemView,omitempty"`
+	CalendarView        *CalendarView        `xml:"m:CalendarView,omitempty"`
+	Restriction         *Restriction         `xml:"m:Restriction,omitempty"`
+	SortOrder           SortOrder            `xml:"m:SortOrder,omitempty"`
+	ParentFolderIds     ParentFolderIds      `xml:"m:ParentFolderIds"`
+}
+
+// IndexedPageItemView pages through the items of a folder by offset
+type IndexedPageItemView struct {
+	MaxEntriesReturned int    `xml:"MaxEntriesReturned,attr,omitempty"`
+	Offset             int    `xml:"Offset,attr"`
+	BasePoint          string `xml:"BasePoint,attr"`
 }
 
 type ItemShape struct {
-	BaseShape string `xml:"t:BaseShape"`
+	BaseShape            BaseShape            `xml:"t:BaseShape"`
+	BodyType             BodyType             `xml:"t:BodyType,omitempty"`
+	AdditionalProperties AdditionalProperties `xml:"t:AdditionalProperties,omitempty"`
 }
 
 type CalendarView struct {
-	StartDate string `xml:"StartDate,attr"`
-	EndDate   string `xml:"EndDate,attr"`
+	MaxEntriesReturned int    `xml:"MaxEntriesReturned,attr,omitempty"`
+	StartDate          string `xml:"StartDate,attr"`
+	EndDate            string `xml:"EndDate,attr"`
 }
 
+// ParentFolderIds holds either a FolderId or a DistinguishedFolderId
 type ParentFolderIds struct {
-	DistinguishedFolderId DistinguishedFolderId `xml:"t:DistinguishedFolderId"`
+	FolderId              *FolderId              `xml:"t:FolderId,omitempty"`
+	DistinguishedFolderId *DistinguishedFolderId `xml:"t:DistinguishedFolderId,omitempty"`
 }
 
+type FolderId struct {
+	Id        string `xml:"Id,attr"`
+	ChangeKey string `xml:"ChangeKey,attr,omitempty"`
+}
+
+// DistinguishedFolderId names a well-known folder, such as "calendar", of the caller's
+// mailbox or of the mailbox given in Mailbox
 type DistinguishedFolderId struct {
-	Id string `xml:"Id,attr"`
+	Id      string         `xml:"Id,attr"`
+	Mailbox *FolderMailbox `xml:"t:Mailbox,omitempty"`
+}
+
+type FolderMailbox struct {
+	EmailAddress string `xml:"t:EmailAddress"`
 }
 
 type CreateEventRequest struct {
@@ -128,22 +239,34 @@ type CreateEventRequest struct {
 }
 
 type CreateEventItems struct {
-	CalendarItem CreateEventCalendarItem `xml:"t:CalendarItem"`
+	CalendarItem []CreateEventCalendarItem `xml:"t:CalendarItem"`
 }
 
 type CreateEventCalendarItem struct {
-	XMLNSt            string             `xml:"xmlns,attr"`
-	Subject           string             `xml:"t:Subject"`
-	Body              ItemBody           `xml:"t:Body"`
-	ReminderIsSet     bool               `xml:"t:ReminderIsSet"`
-	ReminderMinutes   int                `xml:"t:ReminderMinutesBeforeStart"`
-	Start             string             `xml:"t:Start"`
-	End               string             `xml:"t:End"`
-	IsAllDayEvent     bool               `xml:"t:IsAllDayEvent"`
+	XMLNSt            string               `xml:"xmlns,attr"`
+	Subject           string               `xml:"t:Subject"`
+	Sensitivity       Sensitivity          `xml:"t:Sensitivity,omitempty"`
+	Body              ItemBody             `xml:"t:Body"`
+	Categories        *ArrayOfStrings      `xml:"t:Categories,omitempty"`
+	Importance        Importance           `xml:"t:Importance,omitempty"`
+	ReminderIsSet     bool                 `xml:"t:ReminderIsSet"`
+	ReminderMinutes   int                  `xml:"t:ReminderMinutesBeforeStart"`
+	ExtendedProperty  []ExtendedProperty   `xml:"t:ExtendedProperty,omitempty"`
+	Start             string               `xml:"t:Start"`
+	End               string               `xml:"t:End"`
+	IsAllDayEvent     bool                 `xml:"t:IsAllDayEvent"`
 	LegacyFreeBusy    LegacyFreeBusyStatus `xml:"t:LegacyFreeBusyStatus"`
-	Location          string             `xml:"t:Location,omitempty"`
-	RequiredAttendees *RequiredAttendees `xml:"t:RequiredAttendees,omitempty"`
-	OptionalAttendees *OptionalAttendees `xml:"t:OptionalAttendees,omitempty"`
+	Location          string               `xml:"t:Location,omitempty"`
+	RequiredAttendees *RequiredAttendees   `xml:"t:RequiredAttendees,omitempty"`
+	OptionalAttendees *OptionalAttendees   `xml:"t:OptionalAttendees,omitempty"`
+	MeetingTimeZone   *MeetingTimeZone     `xml:"t:MeetingTimeZone,omitempty"`
+	StartTimeZone     *TimeZoneDefinition  `xml:"t:StartTimeZone,omitempty"`
+	EndTimeZone       *TimeZoneDefinition  `xml:"t:EndTimeZone,omitempty"`
+}
+
+// ArrayOfStrings is a list of string values, such as item categories
+type ArrayOfStrings struct {
+	String []string `xml:"t:String"`
 }
 
 type ItemBody struct {
@@ -151,22 +274,79 @@ type ItemBody struct {
 	Content  string `xml:",chardata"`
 }
 
+// SavedItemFolderId holds either a FolderId or a DistinguishedFolderId
 type SavedItemFolderId struct {
-	DistinguishedFolderId DistinguishedFolderId `xml:"t:DistinguishedFolderId"`
+	FolderId              *FolderId              `xml:"t:FolderId,omitempty"`
+	DistinguishedFolderId *DistinguishedFolderId `xml:"t:DistinguishedFolderId,omitempty"`
 }
 
 type DeleteItemRequest struct {
-	XMLName                  xml.Name      `xml:"m:DeleteItem"`
-	XMLNSm                   string        `xml:"xmlns:m,attr"`
-	DeleteType               string        `xml:"DeleteType,attr"`
-	SendMeetingCancellations string        `xml:"SendMeetingCancellations,attr"`
-	ItemIds                  DeleteItemIds `xml:"m:ItemIds"`
+	XMLName                  xml.Name `xml:"m:DeleteItem"`
+	XMLNSm                   string   `xml:"xmlns:m,attr"`
+	DeleteType               string   `xml:"DeleteType,attr"`
+	SendMeetingCancellations string   `xml:"SendMeetingCancellations,attr"`
+	ItemIds                  ItemIds  `xml:"m:ItemIds"`
 }
 
-type DeleteItemIds struct {
+// ItemIds lists the items a request operates on
+type ItemIds struct {
 	ItemId []ItemId `xml:"t:ItemId"`
 }
 
+// DeleteItemIds is the former name of ItemIds.
+//
+// Deprecated: use ItemIds.
+type DeleteItemIds = ItemIds
+
+// MoveItem and CopyItem request structures
+type MoveItemRequest struct {
+	XMLName          xml.Name       `xml:"m:MoveItem"`
+	XMLNSm           string         `xml:"xmlns:m,attr"`
+	ToFolderId       TargetFolderId `xml:"m:ToFolderId"`
+	ItemIds          ItemIds        `xml:"m:ItemIds"`
+	ReturnNewItemIds *bool          `xml:"m:ReturnNewItemIds,omitempty"`
+}
+
+type CopyItemRequest struct {
+	XMLName          xml.Name       `xml:"m:CopyItem"`
+	XMLNSm           string         `xml:"xmlns:m,attr"`
+	ToFolderId       TargetFolderId `xml:"m:ToFolderId"`
+	ItemIds          ItemIds        `xml:"m:ItemIds"`
+	ReturnNewItemIds *bool          `xml:"m:ReturnNewItemIds,omitempty"`
+}
+
+type TargetFolderId struct {
+	FolderId              *FolderId              `xml:"t:FolderId,omitempty"`
+	DistinguishedFolderId *DistinguishedFolderId `xml:"t:DistinguishedFolderId,omitempty"`
+}
+
+// Response structures shared by operations on several items, such as MoveItem and batch requests.
+// The names of the response and message elements depend on the operation, so they are matched with any.
+type ItemResponseEnvelope struct {
+	XMLName xml.Name         `xml:"Envelope"`
+	Body    ItemResponseBody `xml:"Body"`
+}
+
+type ItemResponseBody struct {
+	Response ItemResponse `xml:",any"`
+}
+
+type ItemResponse struct {
+	ResponseMessages ItemResponseMessages `xml:"ResponseMessages"`
+}
+
+type ItemResponseMessages struct {
+	// Messages holds one response message per item, in request order
+	Messages []ItemResponseMessage `xml:",any"`
+}
+
+type ItemResponseMessage struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	MessageText   string `xml:"MessageText"`
+	ResponseCode  string `xml:"ResponseCode"`
+	Items         Items  `xml:"Items"`
+}
+
 // CreateItem response structures
 type CreateItemResponseEnvelope struct {
 	XMLName xml.Name           `xml:"Envelope"`
@@ -207,18 +387,28 @@ type UpdateItemRequest struct {
 }
 
 type ItemChanges struct {
-	ItemChange ItemChange `xml:"t:ItemChange"`
+	ItemChange []ItemChange `xml:"t:ItemChange"`
 }
 type ItemChange struct {
 	ItemId  ItemId  `xml:"t:ItemId"`
 	Updates Updates `xml:"t:Updates"`
 }
 type Updates struct {
-	SetItemField []SetItemField `xml:"t:SetItemField"`
+	SetItemField    []SetItemField    `xml:"t:SetItemField"`
+	DeleteItemField []DeleteItemField `xml:"t:DeleteItemField,omitempty"`
 }
+
+// SetItemField sets a property identified by either FieldURI or ExtendedFieldURI
 type SetItemField struct {
-	FieldURI     FieldURI           `xml:"t:FieldURI"`
-	CalendarItem UpdateCalendarItem `xml:"t:CalendarItem"`
+	FieldURI         *FieldURI          `xml:"t:FieldURI,omitempty"`
+	ExtendedFieldURI *ExtendedFieldURI  `xml:"t:ExtendedFieldURI,omitempty"`
+	CalendarItem     UpdateCalendarItem `xml:"t:CalendarItem"`
+}
+
+// DeleteItemField removes a property identified by either FieldURI or ExtendedFieldURI
+type DeleteItemField struct {
+	FieldURI         *FieldURI         `xml:"t:FieldURI,omitempty"`
+	ExtendedFieldURI *ExtendedFieldURI `xml:"t:ExtendedFieldURI,omitempty"`
 }
 
 type FieldURI struct {
@@ -226,14 +416,24 @@ type FieldURI struct {
 }
 
 type UpdateCalendarItem struct {
-	Start             *string            `xml:"t:Start,omitempty"`
-	End               *string            `xml:"t:End,omitempty"`
-	Subject           *string            `xml:"t:Subject,omitempty"`
-	Body              *ItemBody          `xml:"t:Body,omitempty"`
+	Start             *string               `xml:"t:Start,omitempty"`
+	End               *string               `xml:"t:End,omitempty"`
+	IsAllDayEvent     *bool                 `xml:"t:IsAllDayEvent,omitempty"`
+	Subject           *string               `xml:"t:Subject,omitempty"`
+	Sensitivity       *Sensitivity          `xml:"t:Sensitivity,omitempty"`
+	Body              *ItemBody             `xml:"t:Body,omitempty"`
+	Categories        *ArrayOfStrings       `xml:"t:Categories,omitempty"`
+	Importance        *Importance           `xml:"t:Importance,omitempty"`
+	ReminderIsSet     *bool                 `xml:"t:ReminderIsSet,omitempty"`
+	ReminderMinutes   *int                  `xml:"t:ReminderMinutesBeforeStart,omitempty"`
+	ExtendedProperty  []ExtendedProperty    `xml:"t:ExtendedProperty,omitempty"`
 	LegacyFreeBusy    *LegacyFreeBusyStatus `xml:"t:LegacyFreeBusyStatus,omitempty"`
-	Location          *string            `xml:"t:Location,omitempty"`
-	RequiredAttendees *RequiredAttendees `xml:"t:RequiredAttendees,omitempty"`
-	OptionalAttendees *OptionalAttendees `xml:"t:OptionalAttendees,omitempty"`
+	Location          *string               `xml:"t:Location,omitempty"`
+	RequiredAttendees *RequiredAttendees    `xml:"t:RequiredAttendees,omitempty"`
+	OptionalAttendees *OptionalAttendees    `xml:"t:OptionalAttendees,omitempty"`
+	MeetingTimeZone   *MeetingTimeZone      `xml:"t:MeetingTimeZone,omitempty"`
+	StartTimeZone     *TimeZoneDefinition   `xml:"t:StartTimeZone,omitempty"`
+	EndTimeZone       *TimeZoneDefinition   `xml:"t:EndTimeZone,omitempty"`
 }
 
 type RequiredAttendees struct {
@@ -282,3 +482,170 @@ type UpdateItemResponseMessageType struct {
 	ResponseClass string `xml:"ResponseClass,attr"`
 	ResponseCode  string `xml:"ResponseCode"`
 }
+
+type GetItemRequest struct {
+	XMLName   xml.Name  `xml:"m:GetItem"`
+	XMLNSm    string    `xml:"xmlns:m,attr"`
+	ItemShape ItemShape `xml:"m:ItemShape"`
+	ItemIds   ItemIds   `xml:"m:ItemIds"`
+}
+
+// GetItem response structures
+type GetItemResponseEnvelope struct {
+	XMLName xml.Name            `xml:"Envelope"`
+	Body    GetItemResponseBody `xml:"Body"`
+}
+
+type GetItemResponseBody struct {
+	GetItemResponse GetItemResponseMessage `xml:"GetItemResponse"`
+}
+
+type GetItemResponseMessage struct {
+	ResponseMessages GetItemResponseMessages `xml:"ResponseMessages"`
+}
+
+type GetItemResponseMessages struct {
+	GetItemResponseMessage GetItemResponseMessageType `xml:"GetItemResponseMessage"`
+}
+
+type GetItemResponseMessageType struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	ResponseCode  string `xml:"ResponseCode"`
+	MessageText   string `xml:"MessageText"`
+	Items         Items  `xml:"Items"`
+}
+
+// FileAttachment describes a file attached to an item.
+// Content is never populated here; it is streamed separately by GetAttachment.
+type FileAttachment struct {
+	AttachmentId     AttachmentId `xml:"AttachmentId"`
+	Name             string       `xml:"Name"`
+	ContentType      string       `xml:"ContentType"`
+	ContentId        string       `xml:"ContentId"`
+	Size             int64        `xml:"Size"`
+	LastModifiedTime string       `xml:"LastModifiedTime"`
+	IsInline         bool         `xml:"IsInline"`
+}
+
+// AttachmentId identifies an attachment and the item it belongs to
+type AttachmentId struct {
+	Id                string `xml:"Id,attr"`
+	RootItemId        string `xml:"RootItemId,attr,omitempty"`
+	RootItemChangeKey string `xml:"RootItemChangeKey,attr,omitempty"`
+}
+
+// Attachment request structures
+type CreateAttachmentRequest struct {
+	XMLName      xml.Name          `xml:"m:CreateAttachment"`
+	XMLNSm       string            `xml:"xmlns:m,attr"`
+	ParentItemId ItemId            `xml:"m:ParentItemId"`
+	Attachments  CreateAttachments `xml:"m:Attachments"`
+}
+
+type CreateAttachments struct {
+	FileAttachment []CreateFileAttachment `xml:"t:FileAttachment"`
+}
+
+type CreateFileAttachment struct {
+	Name        string `xml:"t:Name"`
+	ContentType string `xml:"t:ContentType,omitempty"`
+	IsInline    bool   `xml:"t:IsInline"`
+	Content     string `xml:"t:Content"`
+}
+
+type GetAttachmentRequest struct {
+	XMLName       xml.Name      `xml:"m:GetAttachment"`
+	XMLNSm        string        `xml:"xmlns:m,attr"`
+	AttachmentIds AttachmentIds `xml:"m:AttachmentIds"`
+}
+
+type DeleteAttachmentRequest struct {
+	XMLName       xml.Name      `xml:"m:DeleteAttachment"`
+	XMLNSm        string        `xml:"xmlns:m,attr"`
+	AttachmentIds AttachmentIds `xml:"m:AttachmentIds"`
+}
+
+type AttachmentIds struct {
+	AttachmentId []AttachmentId `xml:"t:AttachmentId"`
+}
+
+// Attachment response structures
+type CreateAttachmentResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		CreateAttachmentResponse struct {
+			ResponseMessages struct {
+				CreateAttachmentResponseMessage AttachmentResponseMessageType `xml:"CreateAttachmentResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"CreateAttachmentResponse"`
+	} `xml:"Body"`
+}
+
+type GetAttachmentResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetAttachmentResponse struct {
+			ResponseMessages struct {
+				GetAttachmentResponseMessage AttachmentResponseMessageType `xml:"GetAttachmentResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"GetAttachmentResponse"`
+	} `xml:"Body"`
+}
+
+type DeleteAttachmentResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		DeleteAttachmentResponse struct {
+			ResponseMessages struct {
+				DeleteAttachmentResponseMessage AttachmentResponseMessageType `xml:"DeleteAttachmentResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"DeleteAttachmentResponse"`
+	} `xml:"Body"`
+}
+
+type AttachmentResponseMessageType struct {
+	ResponseClass string           `xml:"ResponseClass,attr"`
+	ResponseCode  string           `xml:"ResponseCode"`
+	MessageText   string           `xml:"MessageText"`
+	Attachments   []FileAttachment `xml:"Attachments>FileAttachment"`
+}
+
+type FindFolderRequest struct {
+	XMLNSm          string          `xml:"xmlns:m,attr"`
+	Traversal       string          `xml:"Traversal,attr"`
+	FolderShape     FolderShape     `xml:"m:FolderShape"`
+	ParentFolderIds ParentFolderIds `xml:"m:ParentFolderIds"`
+}
+
+type FolderShape struct {
+	BaseShape string `xml:"t:BaseShape"`
+}
+
+// FindFolder response structures
+type FindFolderResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		FindFolderResponse struct {
+			ResponseMessages struct {
+				FindFolderResponseMessage FindFolderResponseMessageType `xml:"FindFolderResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"FindFolderResponse"`
+	} `xml:"Body"`
+}
+
+type FindFolderResponseMessageType struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	ResponseCode  string `xml:"ResponseCode"`
+	MessageText   string `xml:"MessageText"`
+	RootFolder    struct {
+		Folders struct {
+			CalendarFolder []FolderInfo `xml:"CalendarFolder"`
+		} `xml:"Folders"`
+	} `xml:"RootFolder"`
+}
+
+// FolderInfo is a folder returned by FindFolder
+type FolderInfo struct {
+	FolderId    FolderId `xml:"FolderId"`
+	DisplayName string   `xml:"DisplayName"`
+}
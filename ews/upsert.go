@@ -0,0 +1,81 @@
+package ews
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ExternalKeyProperty is the extended property UpsertCalendarEvent stores the external key of an event in
+var ExternalKeyProperty = NamedProperty("6f1a4b2e-9c3d-4e8a-b5f7-2d0c8e9a1b34", "ExternalKey", PropertyTypeString)
+
+// UpsertCalendarEvent creates the event, or updates the event previously created with the same
+// external key with the fields set in event. The key is stored in ExternalKeyProperty, so retrying a
+// create that timed out after the server saved the event updates that event instead of creating a
+// duplicate. An empty Location, Sensitivity, Importance, Categories or attendee list leaves the value
+// of the existing event unchanged, so an upsert cannot clear them or remove every attendee.
+// Invitations are sent, for the update too, only when event.SendInvites is set.
+// It returns the ID of the event and whether it was created.
+func (c *EWSClient) UpsertCalendarEvent(ctx context.Context, externalKey string, event CalendarEvent) (*string, bool, error) {
+	if externalKey == "" {
+		return nil, false, fmt.Errorf("external key is required")
+	}
+
+	existing, err := c.FindItems(ctx, FindItemsOptions{
+		Folder:      event.Folder,
+		Restriction: IsEqualTo(ExternalKeyProperty, externalKey),
+		Shape:       Shape{BaseShape: BaseShapeIdOnly},
+		PageSize:    1,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error looking up event by external key: %w", err)
+	}
+
+	if len(existing.CalendarItems) > 0 {
+		itemID := existing.CalendarItems[0].ItemId.Id
+		if err := c.updateCalendarEvent(ctx, itemID, event.updates(), event.sendMeetingInvitations()); err != nil {
+			return nil, false, err
+		}
+		return &itemID, false, nil
+	}
+
+	event.ExtendedProperties = append(slices.Clip(event.ExtendedProperties), NewExtendedProperty(ExternalKeyProperty, externalKey))
+	itemID, err := c.CreateCalendarEventContext(ctx, event)
+	if err != nil {
+		return nil, false, err
+	}
+	return itemID, true, nil
+}
+
+// updates returns the changes that set the fields of e on an existing event
+func (e CalendarEvent) updates() EventUpdates {
+	reminderIsSet, reminderMinutes := e.reminder()
+	freeBusy := e.freeBusy()
+
+	updates := EventUpdates{
+		Start:              &e.Start,
+		End:                &e.End,
+		Subject:            &e.Subject,
+		Body:               &e.Body,
+		BodyType:           e.BodyType,
+		LegacyFreeBusy:     &freeBusy,
+		IsAllDay:           &e.IsAllDay,
+		RequiredAttendees:  e.RequiredAttendees,
+		OptionalAttendees:  e.OptionalAttendees,
+		ReminderIsSet:      &reminderIsSet,
+		ReminderMinutes:    &reminderMinutes,
+		Categories:         e.Categories,
+		ExtendedProperties: e.ExtendedProperties,
+	}
+	if e.Location != "" {
+		updates.Location = &e.Location
+	}
+	if e.Sensitivity != "" {
+		updates.Sensitivity = &e.Sensitivity
+	}
+	if e.Importance != "" {
+		updates.Importance = &e.Importance
+	}
+
+	return updates
+}
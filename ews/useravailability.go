@@ -0,0 +1,424 @@
+package ews
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FreeBusyViewType selects the detail GetUserAvailability returns for each mailbox
+type FreeBusyViewType string
+
+// FreeBusyViewType constants
+const (
+	FreeBusyViewMergedOnly     FreeBusyViewType = "MergedOnly"     // Only the merged free/busy string
+	FreeBusyViewFreeBusy       FreeBusyViewType = "FreeBusy"       // Events with their status
+	FreeBusyViewFreeBusyMerged FreeBusyViewType = "FreeBusyMerged" // Events and the merged string
+	FreeBusyViewDetailed       FreeBusyViewType = "Detailed"       // Events with subject and location where permitted
+	FreeBusyViewDetailedMerged FreeBusyViewType = "DetailedMerged" // Detailed events and the merged string
+)
+
+// maxAvailabilityMailboxes is the number of mailboxes the server accepts in one GetUserAvailability request
+const maxAvailabilityMailboxes = 100
+
+// FreeBusyOptions controls the free/busy information returned by GetUserAvailability
+type FreeBusyOptions struct {
+	View           FreeBusyViewType // Defaults to FreeBusyViewDetailedMerged
+	MergedInterval time.Duration    // Length of each merged free/busy interval; defaults to 30 minutes
+}
+
+// AttendeeAvailability is the free/busy information of one mailbox returned by GetUserAvailability
+type AttendeeAvailability struct {
+	Email          string
+	View           FreeBusyViewType // View returned, which may have less detail than requested
+	Events         []FreeBusyEvent  // Events occupying time, unless the view is MergedOnly
+	MergedFreeBusy []BusyInterval   // Consecutive merged intervals with the same status, for the merged views
+	WorkingHours   *WorkingHours    // Working hours and time zone of the mailbox, when it has them set
+	Err            error            // *ResponseError if the server could not return the mailbox's free/busy
+}
+
+// FreeBusyEvent is an event in a mailbox's free/busy information
+type FreeBusyEvent struct {
+	Start   time.Time
+	End     time.Time
+	Status  LegacyFreeBusyStatus
+	Details *CalendarEventDetails // Only for the detailed views, when the caller may see them
+}
+
+// BusyIntervals returns the time occupied in the mailbox, from its events when the view has them and
+// from the merged free/busy intervals otherwise
+func (a AttendeeAvailability) BusyIntervals() []BusyInterval {
+	if len(a.Events) == 0 && len(a.MergedFreeBusy) > 0 {
+		return slices.Clone(a.MergedFreeBusy)
+	}
+
+	intervals := make([]BusyInterval, 0, len(a.Events))
+	for _, event := range a.Events {
+		intervals = append(intervals, BusyInterval{Start: event.Start, End: event.End, Status: event.Status})
+	}
+	return intervals
+}
+
+type GetUserAvailabilityRequest struct {
+	XMLNSm                 string                  `xml:"xmlns:m,attr"`
+	TimeZone               SerializableTimeZone    `xml:"t:TimeZone"`
+	MailboxDataArray       MailboxDataArray        `xml:"m:MailboxDataArray"`
+	FreeBusyViewOptions    *FreeBusyViewOptions    `xml:"t:FreeBusyViewOptions,omitempty"`
+	SuggestionsViewOptions *SuggestionsViewOptions `xml:"t:SuggestionsViewOptions,omitempty"`
+}
+
+// SerializableTimeZone describes a time zone in the format of the availability service.
+// Biases are in minutes to add to local time to get UTC.
+type SerializableTimeZone struct {
+	Bias         int                      `xml:"t:Bias"`
+	StandardTime SerializableTimeZoneTime `xml:"t:StandardTime"`
+	DaylightTime SerializableTimeZoneTime `xml:"t:DaylightTime"`
+}
+
+// SerializableTimeZoneTime is the yearly switch to standard or daylight time of a SerializableTimeZone
+type SerializableTimeZoneTime struct {
+	Bias      int    `xml:"t:Bias"`
+	Time      string `xml:"t:Time"`
+	DayOrder  int    `xml:"t:DayOrder"` // 1-4, or 5 for the last occurrence in the month
+	Month     int    `xml:"t:Month"`
+	DayOfWeek string `xml:"t:DayOfWeek"`
+}
+
+type MailboxDataArray struct {
+	MailboxData []MailboxData `xml:"t:MailboxData"`
+}
+
+type MailboxData struct {
+	Email            MailboxAddress `xml:"t:Email"`
+	AttendeeType     string         `xml:"t:AttendeeType"`
+	ExcludeConflicts bool           `xml:"t:ExcludeConflicts"`
+}
+
+type MailboxAddress struct {
+	Address string `xml:"t:Address"`
+}
+
+type FreeBusyViewOptions struct {
+	TimeWindow                      TimeWindow       `xml:"t:TimeWindow"`
+	MergedFreeBusyIntervalInMinutes int              `xml:"t:MergedFreeBusyIntervalInMinutes"`
+	RequestedView                   FreeBusyViewType `xml:"t:RequestedView"`
+}
+
+type TimeWindow struct {
+	StartTime string `xml:"t:StartTime"`
+	EndTime   string `xml:"t:EndTime"`
+}
+
+// GetUserAvailability response structures
+type GetUserAvailabilityResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetUserAvailabilityResponse struct {
+			FreeBusyResponses   []FreeBusyResponse   `xml:"FreeBusyResponseArray>FreeBusyResponse"`
+			SuggestionsResponse *SuggestionsResponse `xml:"SuggestionsResponse"`
+		} `xml:"GetUserAvailabilityResponse"`
+	} `xml:"Body"`
+}
+
+type FreeBusyResponse struct {
+	ResponseMessage struct {
+		ResponseClass string `xml:"ResponseClass,attr"`
+		ResponseCode  string `xml:"ResponseCode"`
+		MessageText   string `xml:"MessageText"`
+	} `xml:"ResponseMessage"`
+	FreeBusyView FreeBusyView `xml:"FreeBusyView"`
+}
+
+type FreeBusyView struct {
+	FreeBusyViewType FreeBusyViewType        `xml:"FreeBusyViewType"`
+	MergedFreeBusy   string                  `xml:"MergedFreeBusy"`
+	CalendarEvents   []FreeBusyCalendarEvent `xml:"CalendarEventArray>CalendarEvent"`
+	WorkingHours     *FreeBusyWorkingHours   `xml:"WorkingHours"`
+}
+
+type FreeBusyCalendarEvent struct {
+	StartTime            string                `xml:"StartTime"`
+	EndTime              string                `xml:"EndTime"`
+	BusyType             LegacyFreeBusyStatus  `xml:"BusyType"`
+	CalendarEventDetails *CalendarEventDetails `xml:"CalendarEventDetails"`
+}
+
+// CalendarEventDetails are the details of a free/busy event visible to the caller
+type CalendarEventDetails struct {
+	ID            string `xml:"ID"`
+	Subject       string `xml:"Subject"`
+	Location      string `xml:"Location"`
+	IsMeeting     bool   `xml:"IsMeeting"`
+	IsRecurring   bool   `xml:"IsRecurring"`
+	IsException   bool   `xml:"IsException"`
+	IsReminderSet bool   `xml:"IsReminderSet"`
+	IsPrivate     bool   `xml:"IsPrivate"`
+}
+
+type FreeBusyWorkingHours struct {
+	TimeZone struct {
+		Bias         int                  `xml:"Bias"`
+		StandardTime freeBusyTimeZoneTime `xml:"StandardTime"`
+		DaylightTime freeBusyTimeZoneTime `xml:"DaylightTime"`
+	} `xml:"TimeZone"`
+	WorkingPeriods []struct {
+		DayOfWeek          string `xml:"DayOfWeek"`
+		StartTimeInMinutes int    `xml:"StartTimeInMinutes"`
+		EndTimeInMinutes   int    `xml:"EndTimeInMinutes"`
+	} `xml:"WorkingPeriodArray>WorkingPeriod"`
+}
+
+type freeBusyTimeZoneTime struct {
+	Bias      int    `xml:"Bias"`
+	Time      string `xml:"Time"`
+	DayOrder  int    `xml:"DayOrder"`
+	Month     int    `xml:"Month"`
+	DayOfWeek string `xml:"DayOfWeek"`
+}
+
+// NewSerializableTimeZone builds the availability service description of the location t is in,
+// using the daylight saving rules that apply in the year of t
+func NewSerializableTimeZone(t time.Time) SerializableTimeZone {
+	rules := zoneRulesFor(t)
+	zone := SerializableTimeZone{Bias: int(-rules.standardOffset / time.Minute)}
+	if !rules.hasDaylight {
+		// Both switches are required; identical ones without a bias never change the offset
+		noChange := SerializableTimeZoneTime{Time: "00:00:00", DayOrder: 1, Month: 1, DayOfWeek: "Sunday"}
+		zone.StandardTime = noChange
+		zone.DaylightTime = noChange
+		return zone
+	}
+
+	zone.StandardTime = serializableTimeZoneTime(rules.toStandard, 0)
+	zone.DaylightTime = serializableTimeZoneTime(rules.toDaylight, -(rules.daylightOffset-rules.standardOffset)/time.Minute)
+	return zone
+}
+
+func serializableTimeZoneTime(tr zoneTransition, bias time.Duration) SerializableTimeZoneTime {
+	tod := tr.timeOfDay
+	return SerializableTimeZoneTime{
+		Bias:      int(bias),
+		Time:      fmt.Sprintf("%02d:%02d:%02d", int(tod.Hours()), int(tod.Minutes())%60, int(tod.Seconds())%60),
+		DayOrder:  tr.occurrence,
+		Month:     int(tr.month),
+		DayOfWeek: tr.weekday.String(),
+	}
+}
+
+// sameRules reports whether the zones have the same offsets and, if they observe daylight time, switches
+func (z SerializableTimeZone) sameRules(other SerializableTimeZone) bool {
+	if z.Bias != other.Bias || z.DaylightTime.Bias != other.DaylightTime.Bias {
+		return false
+	}
+	return z.DaylightTime.Bias == 0 || z == other
+}
+
+var (
+	zoneLocationsMu sync.Mutex
+	zoneLocations   = make(map[serializableZoneKey]*time.Location)
+)
+
+type serializableZoneKey struct {
+	zone SerializableTimeZone
+	year int
+}
+
+// Location returns a location with the rules of the zone in the given year, preferring the first of
+// preferred that matches, then the zones of the Windows mapping table. A zone matching none of them
+// is returned as a fixed offset without daylight saving time.
+func (z SerializableTimeZone) Location(year int, preferred ...*time.Location) *time.Location {
+	for _, loc := range preferred {
+		if loc != nil && NewSerializableTimeZone(time.Date(year, time.January, 1, 0, 0, 0, 0, loc)).sameRules(z) {
+			return loc
+		}
+	}
+
+	key := serializableZoneKey{zone: z, year: year}
+	zoneLocationsMu.Lock()
+	defer zoneLocationsMu.Unlock()
+	if loc, ok := zoneLocations[key]; ok {
+		return loc
+	}
+
+	loadWindowsZones()
+	ids := make([]string, 0, len(ianaByWindows))
+	for id := range ianaByWindows {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	loc := time.FixedZone(fmt.Sprintf("UTC%+03d:%02d", -z.Bias/60, abs(z.Bias%60)), -z.Bias*60)
+	for _, id := range ids {
+		candidate, err := LoadWindowsLocation(id)
+		if err == nil && NewSerializableTimeZone(time.Date(year, time.January, 1, 0, 0, 0, 0, candidate)).sameRules(z) {
+			loc = candidate
+			break
+		}
+	}
+	zoneLocations[key] = loc
+	return loc
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// mergedStatuses maps the digits of the merged free/busy string to statuses
+var mergedStatuses = map[rune]LegacyFreeBusyStatus{
+	'0': Free,
+	'1': Tentative,
+	'2': Busy,
+	'3': OOF,
+	'4': NoData,
+}
+
+// NewUserAvailabilityRequest builds the GetUserAvailability request for the mailboxes between start
+// and end. Times are sent and returned in the location of start.
+func NewUserAvailabilityRequest(emails []string, start, end time.Time, opts FreeBusyOptions) *GetUserAvailabilityRequest {
+	view := opts.View
+	if view == "" {
+		view = FreeBusyViewDetailedMerged
+	}
+	interval := opts.MergedInterval
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+
+	request := &GetUserAvailabilityRequest{
+		XMLNSm:   "http://schemas.microsoft.com/exchange/services/2006/messages",
+		TimeZone: NewSerializableTimeZone(start),
+		FreeBusyViewOptions: &FreeBusyViewOptions{
+			TimeWindow: TimeWindow{
+				StartTime: start.Format("2006-01-02T15:04:05"),
+				EndTime:   end.In(start.Location()).Format("2006-01-02T15:04:05"),
+			},
+			MergedFreeBusyIntervalInMinutes: int(interval / time.Minute),
+			RequestedView:                   view,
+		},
+	}
+	for _, email := range emails {
+		request.MailboxDataArray.MailboxData = append(request.MailboxDataArray.MailboxData, MailboxData{
+			Email:        MailboxAddress{Address: email},
+			AttendeeType: "Required",
+		})
+	}
+	return request
+}
+
+// Availability converts the free/busy response for the mailbox email of a request built by
+// NewUserAvailabilityRequest for start and opts
+func (r FreeBusyResponse) Availability(email string, start time.Time, opts FreeBusyOptions) AttendeeAvailability {
+	availability := AttendeeAvailability{Email: email, View: r.FreeBusyView.FreeBusyViewType}
+	if message := r.ResponseMessage; message.ResponseClass != "Success" {
+		availability.Err = &ResponseError{ResponseClass: message.ResponseClass, ResponseCode: message.ResponseCode, MessageText: message.MessageText}
+		return availability
+	}
+
+	loc := start.Location()
+	for _, event := range r.FreeBusyView.CalendarEvents {
+		eventStart, err := parseAvailabilityTime(event.StartTime, loc)
+		if err != nil {
+			availability.Err = err
+			return availability
+		}
+		eventEnd, err := parseAvailabilityTime(event.EndTime, loc)
+		if err != nil {
+			availability.Err = err
+			return availability
+		}
+		availability.Events = append(availability.Events, FreeBusyEvent{
+			Start:   eventStart,
+			End:     eventEnd,
+			Status:  event.BusyType,
+			Details: event.CalendarEventDetails,
+		})
+	}
+
+	interval := opts.MergedInterval
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+	for i, digit := range r.FreeBusyView.MergedFreeBusy {
+		status, ok := mergedStatuses[digit]
+		if !ok {
+			status = NoData
+		}
+		intervalStart := start.Add(time.Duration(i) * interval)
+		if n := len(availability.MergedFreeBusy); n > 0 && availability.MergedFreeBusy[n-1].Status == status {
+			availability.MergedFreeBusy[n-1].End = intervalStart.Add(interval)
+			continue
+		}
+		availability.MergedFreeBusy = append(availability.MergedFreeBusy, BusyInterval{Start: intervalStart, End: intervalStart.Add(interval), Status: status})
+	}
+
+	if hours := r.FreeBusyView.WorkingHours; hours != nil {
+		zone := SerializableTimeZone{
+			Bias:         hours.TimeZone.Bias,
+			StandardTime: SerializableTimeZoneTime(hours.TimeZone.StandardTime),
+			DaylightTime: SerializableTimeZoneTime(hours.TimeZone.DaylightTime),
+		}
+		availability.WorkingHours = &WorkingHours{
+			Location: zone.Location(start.Year(), loc),
+			Days:     make(map[time.Weekday][]WorkingWindow),
+		}
+		for _, period := range hours.WorkingPeriods {
+			window := WorkingWindow{
+				Start: time.Duration(period.StartTimeInMinutes) * time.Minute,
+				End:   time.Duration(period.EndTimeInMinutes) * time.Minute,
+			}
+			for _, name := range strings.Fields(period.DayOfWeek) {
+				for _, day := range workDays[name] {
+					availability.WorkingHours.Days[day] = append(availability.WorkingHours.Days[day], window)
+				}
+			}
+		}
+	}
+
+	return availability
+}
+
+// parseAvailabilityTime parses a time returned by GetUserAvailability, which is in the request's
+// time zone and carries no offset
+func parseAvailabilityTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05", s, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing free/busy time %q: %w", s, err)
+	}
+	return t.In(loc), nil
+}
+
+// GetUserAvailability returns the free/busy information of the mailboxes between start and end,
+// in the order of emails. Unlike GetCalendarItems it only needs free/busy permission on each
+// mailbox and reads many mailboxes in one request. The server limits the range to 42 days.
+func (c *EWSClient) GetUserAvailability(ctx context.Context, emails []string, start, end time.Time, opts FreeBusyOptions) ([]AttendeeAvailability, error) {
+	start = start.In(c.TimeZone)
+
+	availability := make([]AttendeeAvailability, 0, len(emails))
+	for chunk := range slices.Chunk(emails, maxAvailabilityMailboxes) {
+		var responseEnvelope GetUserAvailabilityResponseEnvelope
+		request := NewUserAvailabilityRequest(chunk, start, end, opts)
+		if err := c.doRequest(ctx, Body{GetUserAvailability: request}, &responseEnvelope); err != nil {
+			return nil, err
+		}
+
+		responses := responseEnvelope.Body.GetUserAvailabilityResponse.FreeBusyResponses
+		if len(responses) != len(chunk) {
+			return nil, fmt.Errorf("expected %d free/busy responses, got %d", len(chunk), len(responses))
+		}
+		for i, response := range responses {
+			availability = append(availability, response.Availability(chunk[i], start, opts))
+		}
+	}
+
+	return availability, nil
+}
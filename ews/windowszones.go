@@ -0,0 +1,103 @@
+package ews
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed windowszones.csv
+var windowsZonesCSV string
+
+var (
+	windowsZonesOnce sync.Once
+	ianaByWindows    map[string]string // Windows ID -> canonical IANA name
+	windowsByIANA    map[string]string // any mapped IANA name -> Windows ID
+)
+
+// loadWindowsZones parses the embedded IANA <-> Windows mapping table
+func loadWindowsZones() {
+	windowsZonesOnce.Do(func() {
+		ianaByWindows = make(map[string]string)
+		windowsByIANA = make(map[string]string)
+
+		for _, line := range strings.Split(windowsZonesCSV, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			windowsID, names, ok := strings.Cut(line, ",")
+			if !ok {
+				continue
+			}
+			for i, name := range strings.Fields(names) {
+				if i == 0 {
+					ianaByWindows[windowsID] = name
+				}
+				if _, exists := windowsByIANA[name]; !exists {
+					windowsByIANA[name] = windowsID
+				}
+			}
+		}
+	})
+}
+
+// WindowsZoneID returns the Windows time zone ID, such as "AUS Eastern Standard Time",
+// for an IANA zone name such as "Australia/Sydney"
+func WindowsZoneID(ianaName string) (string, bool) {
+	loadWindowsZones()
+	id, ok := windowsByIANA[ianaName]
+	return id, ok
+}
+
+// IANAZoneName returns the canonical IANA zone name for a Windows time zone ID
+func IANAZoneName(windowsID string) (string, bool) {
+	loadWindowsZones()
+	name, ok := ianaByWindows[windowsID]
+	return name, ok
+}
+
+// LoadWindowsLocation returns the *time.Location for a Windows time zone ID. It returns
+// ErrUnknownTimeZone for IDs missing from the mapping table.
+func LoadWindowsLocation(windowsID string) (*time.Location, error) {
+	name, ok := IANAZoneName(windowsID)
+	if !ok {
+		return nil, fmt.Errorf("%w: Windows time zone %q is not in the mapping table", ErrUnknownTimeZone, windowsID)
+	}
+	return time.LoadLocation(name)
+}
+
+// WindowsZoneIDForLocation returns the Windows time zone ID matching loc.
+// time.Local is resolved through the TZ environment variable or /etc/localtime.
+func WindowsZoneIDForLocation(loc *time.Location) (string, bool) {
+	return WindowsZoneID(ianaName(loc))
+}
+
+// ianaName returns the IANA name of loc, resolving time.Local where possible
+func ianaName(loc *time.Location) string {
+	name := loc.String()
+	if name != "Local" {
+		return name
+	}
+
+	if tz := os.Getenv("TZ"); tz != "" {
+		return strings.TrimPrefix(tz, ":")
+	}
+	if target, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if _, zone, ok := strings.Cut(target, "zoneinfo/"); ok {
+			return zone
+		}
+	}
+
+	return name
+}
+
+// TimeZoneDefinitionForLocation builds the Exchange 2010 time zone definition of loc,
+// using the daylight saving rules in effect this year
+func TimeZoneDefinitionForLocation(loc *time.Location) TimeZoneDefinition {
+	return NewTimeZoneDefinition(time.Now().In(loc))
+}
@@ -0,0 +1,241 @@
+package ews
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WorkingWindow is a period of working time within a day, as offsets from midnight
+type WorkingWindow struct {
+	Start time.Duration // e.g. 9 * time.Hour
+	End   time.Duration // e.g. 17*time.Hour + 30*time.Minute
+}
+
+// WorkingHours are the periods of each weekday in which time can be booked
+type WorkingHours struct {
+	Location *time.Location                   // Time zone of the windows; defaults to the location of the searched range
+	Days     map[time.Weekday][]WorkingWindow // Days without windows are not working days
+}
+
+// NewWorkingHours returns working hours with the same window on each of days, Monday to Friday when no days are given
+func NewWorkingHours(loc *time.Location, start, end time.Duration, days ...time.Weekday) *WorkingHours {
+	if len(days) == 0 {
+		days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	hours := &WorkingHours{Location: loc, Days: make(map[time.Weekday][]WorkingWindow)}
+	for _, day := range days {
+		hours.Days[day] = []WorkingWindow{{Start: start, End: end}}
+	}
+	return hours
+}
+
+// closedIntervals returns the time between start and end outside the working hours of opts or on its
+// excluded dates. Days are those of the working hours' time zone, or of start's if there is none.
+func (o AvailabilityOptions) closedIntervals(start, end time.Time) []BusyInterval {
+	if o.WorkingHours == nil && len(o.ExcludedDates) == 0 {
+		return nil
+	}
+
+	loc := o.location(start)
+	var closed []BusyInterval
+	for day := midnight(start.In(loc)); day.Before(end); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		if o.excludes(day) {
+			closed = append(closed, BusyInterval{Start: day, End: next, Status: OOF})
+			continue
+		}
+		if o.WorkingHours == nil {
+			continue
+		}
+
+		windows := slices.Clone(o.WorkingHours.Days[day.Weekday()])
+		slices.SortFunc(windows, func(a, b WorkingWindow) int {
+			return int(a.Start - b.Start)
+		})
+
+		open := day // Start of the time not yet covered by a window
+		for _, window := range windows {
+			// Offsets are wall clock times, so they are also correct on days with a DST change
+			windowStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, int(window.Start), loc)
+			windowEnd := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, int(window.End), loc)
+			if windowStart.After(open) {
+				closed = append(closed, BusyInterval{Start: open, End: windowStart, Status: OOF})
+			}
+			if windowEnd.After(open) {
+				open = windowEnd
+			}
+		}
+		if next.After(open) {
+			closed = append(closed, BusyInterval{Start: open, End: next, Status: OOF})
+		}
+	}
+
+	return closed
+}
+
+// excludes reports whether day is one of the excluded dates
+func (o AvailabilityOptions) excludes(day time.Time) bool {
+	for _, date := range o.ExcludedDates {
+		if date.Year() == day.Year() && date.Month() == day.Month() && date.Day() == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the slot lies entirely within the working hours of opts, not on an excluded
+// date and within its minimum notice and maximum lookahead
+func (o AvailabilityOptions) Allows(slot TimeSlot) bool {
+	for _, closed := range append(o.closedIntervals(slot.Start, slot.End), o.noticeIntervals(slot.Start, slot.End)...) {
+		if closed.Start.Before(slot.End) && closed.End.After(slot.Start) {
+			return false
+		}
+	}
+	return true
+}
+
+type GetUserConfigurationRequest struct {
+	XMLNSm                      string                `xml:"xmlns:m,attr"`
+	UserConfigurationName       UserConfigurationName `xml:"m:UserConfigurationName"`
+	UserConfigurationProperties string                `xml:"m:UserConfigurationProperties"`
+}
+
+type UserConfigurationName struct {
+	Name                  string                `xml:"Name,attr"`
+	DistinguishedFolderId DistinguishedFolderId `xml:"t:DistinguishedFolderId"`
+}
+
+// GetUserConfiguration response structures
+type GetUserConfigurationResponseEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		GetUserConfigurationResponse struct {
+			ResponseMessages struct {
+				GetUserConfigurationResponseMessage GetUserConfigurationResponseMessageType `xml:"GetUserConfigurationResponseMessage"`
+			} `xml:"ResponseMessages"`
+		} `xml:"GetUserConfigurationResponse"`
+	} `xml:"Body"`
+}
+
+type GetUserConfigurationResponseMessageType struct {
+	ResponseClass string `xml:"ResponseClass,attr"`
+	ResponseCode  string `xml:"ResponseCode"`
+	MessageText   string `xml:"MessageText"`
+	XmlData       string `xml:"UserConfiguration>XmlData"` // Base64 encoded
+}
+
+// NewWorkHoursRequest returns the request reading the WorkHours configuration of the calendar folder
+func NewWorkHoursRequest() *GetUserConfigurationRequest {
+	return &GetUserConfigurationRequest{
+		XMLNSm: "http://schemas.microsoft.com/exchange/services/2006/messages",
+		UserConfigurationName: UserConfigurationName{
+			Name:                  "WorkHours",
+			DistinguishedFolderId: DistinguishedFolderId{Id: "calendar"},
+		},
+		UserConfigurationProperties: "XmlData",
+	}
+}
+
+// workHoursData is the document stored in the WorkHours configuration
+type workHoursData struct {
+	TimeZone struct {
+		Bias int    `xml:"Bias"` // Minutes to add to local time to get UTC
+		Name string `xml:"Name"` // Windows time zone ID
+	} `xml:"WorkHoursVersion1>TimeZone"`
+	TimeSlots []struct {
+		Start string `xml:"Start"`
+		End   string `xml:"End"`
+	} `xml:"WorkHoursVersion1>TimeSlot"`
+	WorkDays string `xml:"WorkHoursVersion1>WorkDays"`
+}
+
+// ParseWorkingHours parses the base64 encoded XmlData of the WorkHours configuration, which holds
+// the working hours set in the mailbox owner's calendar options
+func ParseWorkingHours(xmlData string) (*WorkingHours, error) {
+	document, err := base64.StdEncoding.DecodeString(strings.TrimSpace(xmlData))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding working hours: %w", err)
+	}
+
+	var data workHoursData
+	if err := xml.Unmarshal(document, &data); err != nil {
+		return nil, fmt.Errorf("error parsing working hours: %w", err)
+	}
+
+	loc, err := resolveZone(data.TimeZone.Name)
+	if err != nil {
+		// Fall back to the bias, which ignores daylight saving time
+		loc = time.FixedZone(data.TimeZone.Name, -data.TimeZone.Bias*60)
+	}
+
+	var windows []WorkingWindow
+	for _, slot := range data.TimeSlots {
+		start, err := parseTimeOfDay(slot.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(slot.End)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, WorkingWindow{Start: start, End: end})
+	}
+
+	hours := &WorkingHours{Location: loc, Days: make(map[time.Weekday][]WorkingWindow)}
+	for _, name := range strings.Fields(data.WorkDays) {
+		days, ok := workDays[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown work day %q", name)
+		}
+		for _, day := range days {
+			hours.Days[day] = windows
+		}
+	}
+
+	return hours, nil
+}
+
+// workDays maps the DayOfWeekType values of the WorkHours configuration to weekdays
+var workDays = map[string][]time.Weekday{
+	"Sunday":     {time.Sunday},
+	"Monday":     {time.Monday},
+	"Tuesday":    {time.Tuesday},
+	"Wednesday":  {time.Wednesday},
+	"Thursday":   {time.Thursday},
+	"Friday":     {time.Friday},
+	"Saturday":   {time.Saturday},
+	"Day":        {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+	"Weekday":    {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"WeekendDay": {time.Saturday, time.Sunday},
+}
+
+// parseTimeOfDay parses a time of day such as "08:30:00" into an offset from midnight
+func parseTimeOfDay(s string) (time.Duration, error) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(s, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+// GetWorkingHours reads the working hours configured in the mailbox's calendar options,
+// for use as AvailabilityOptions.WorkingHours
+func (c *EWSClient) GetWorkingHours(ctx context.Context) (*WorkingHours, error) {
+	var responseEnvelope GetUserConfigurationResponseEnvelope
+	if err := c.doRequest(ctx, Body{GetUserConfiguration: NewWorkHoursRequest()}, &responseEnvelope); err != nil {
+		return nil, err
+	}
+
+	// Check response code
+	responseMessage := responseEnvelope.Body.GetUserConfigurationResponse.ResponseMessages.GetUserConfigurationResponseMessage
+	if responseMessage.ResponseClass != "Success" {
+		return nil, fmt.Errorf("EWS error: %s", responseMessage.ResponseCode)
+	}
+
+	return ParseWorkingHours(responseMessage.XmlData)
+}
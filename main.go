@@ -47,7 +47,7 @@ func main() {
 		// basicClient.SetTimezone("Australia/Sydney") // Optional: Set timezone if needed
 
 		fmt.Printf("Attempting to get calendar items for user: %s (via basic auth)\n", ewsUsername)
-		basicItems, err := basicClient.GetCalendarItems(startDate, endDate)
+		basicItems, err := basicClient.GetEvents(ctx, startDate, endDate)
 		if err != nil {
 			log.Printf("Error getting calendar items (basic auth): %v\n", err)
 		} else {
@@ -56,16 +56,14 @@ func main() {
 			} else {
 				fmt.Printf("Found %d calendar items (basic auth):\n", len(basicItems))
 				for _, item := range basicItems {
-					startTime, _ := basicClient.ParseDateTime(item.Start)
-					endTime, _ := basicClient.ParseDateTime(item.End)
 					allDayText := "No"
-					if item.IsAllDayEvent {
+					if item.IsAllDay {
 						allDayText = "Yes"
 					}
 					fmt.Printf("  Subject: %s, Start: %s, End: %s, All-Day: %s, ID: %s\n",
 						item.Subject,
-						startTime.Format(time.RFC1123),
-						endTime.Format(time.RFC1123),
+						item.Start.Format(time.RFC1123),
+						item.End.Format(time.RFC1123),
 						allDayText,
 						item.ItemId.Id,
 					)
@@ -87,7 +85,7 @@ func main() {
 			// impersonationClient.SetTimezone("Australia/Sydney") // Optional: Set timezone if needed
 
 			fmt.Printf("Attempting to get calendar items for user: %s (via impersonation for %s)\n", ewsUsername, impersonatedUserEmail)
-			impersonatedItems, err := impersonationClient.GetCalendarItems(ctx, startDate, endDate, impersonatedUserEmail)
+			impersonatedItems, err := impersonationClient.GetEvents(ctx, startDate, endDate, impersonatedUserEmail)
 			if err != nil {
 				log.Printf("Error getting calendar items (impersonation): %v\n", err)
 			} else {
@@ -96,16 +94,14 @@ func main() {
 				} else {
 					fmt.Printf("Found %d calendar items (impersonation for %s):\n", len(impersonatedItems), impersonatedUserEmail)
 					for _, item := range impersonatedItems {
-						startTime, _ := impersonationClient.ParseDateTime(item.Start)
-						endTime, _ := impersonationClient.ParseDateTime(item.End)
 						allDayText := "No"
-						if item.IsAllDayEvent {
+						if item.IsAllDay {
 							allDayText = "Yes"
 						}
 						fmt.Printf("  Subject: %s, Start: %s, End: %s, All-Day: %s, ID: %s\n",
 							item.Subject,
-							startTime.Format(time.RFC1123),
-							endTime.Format(time.RFC1123),
+							item.Start.Format(time.RFC1123),
+							item.End.Format(time.RFC1123),
 							allDayText,
 							item.ItemId.Id,
 						)